@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+)
+
+// clickhouseClient is how resources and data sources talk to the server. It
+// is handed to them as provider data, and tests replace it with a fake.
+type clickhouseClient interface {
+	// Exec runs a statement that returns no rows. It is only retried when
+	// it could not be sent.
+	Exec(ctx context.Context, query string, args ...any) error
+	// Query runs a read-only statement and returns its rows, retrying
+	// transient failures.
+	Query(ctx context.Context, query string, args ...any) (driver.Rows, error)
+	// QueryRow runs a read-only statement and returns its first row,
+	// retrying transient failures.
+	QueryRow(ctx context.Context, query string, args ...any) driver.Row
+	// ExecDDL runs a DDL statement. When it runs ON CLUSTER, the status
+	// reported by every host is checked.
+	ExecDDL(ctx context.Context, query string, args ...any) error
+	// Cluster returns the cluster DDL statements run on when a resource
+	// does not set on_cluster. It is empty when no default is configured.
+	Cluster() string
+	// Server describes the connected server.
+	Server() *serverInfo
+}
+
+// providerClient is the clickhouseClient built by the provider's Configure
+// method.
+type providerClient struct {
+	conn    clickhouse.Conn
+	cluster string
+	server  *serverInfo
+	retry   retryPolicy
+	// waitDDL makes ExecDDL wait until the distributed DDL queue shows the
+	// statement finished on every host.
+	waitDDL bool
+	// settings are applied to every statement.
+	settings clickhouse.Settings
+}
+
+// Exec runs a statement that returns no rows. Statements that change state
+// are not idempotent, so only failures to reach the server are retried.
+func (c *providerClient) Exec(ctx context.Context, query string, args ...any) error {
+	ctx, statement := withRedactedStatement(c.queryContext(ctx), query)
+	start := time.Now()
+	err := checkTimeout(ctx, c.sendOnce(ctx, func() error {
+		return c.conn.Exec(ctx, query, args...)
+	}))
+	logStatement(ctx, statement, start, err)
+	return err
+}
+
+// Query runs a read-only statement and returns its rows, retrying transient
+// failures. Errors met while iterating over the rows are not retried.
+func (c *providerClient) Query(ctx context.Context, query string, args ...any) (driver.Rows, error) {
+	return c.query(ctx, c.retryRead, query, args...)
+}
+
+// QueryRow runs a read-only statement and returns its first row, retrying
+// transient failures.
+func (c *providerClient) QueryRow(ctx context.Context, query string, args ...any) driver.Row {
+	ctx, statement := withRedactedStatement(c.queryContext(ctx), query)
+	start := time.Now()
+	var row driver.Row
+	err := c.retryRead(ctx, func() error {
+		row = c.conn.QueryRow(ctx, query, args...)
+		return row.Err()
+	})
+	logStatement(ctx, statement, start, checkTimeout(ctx, err))
+	return &providerRow{Row: row, ctx: ctx}
+}
+
+// query runs a statement returning rows, sending it with send.
+func (c *providerClient) query(ctx context.Context, send func(context.Context, func() error) error, query string, args ...any) (driver.Rows, error) {
+	ctx, statement := withRedactedStatement(c.queryContext(ctx), query)
+	start := time.Now()
+	var rows driver.Rows
+	err := checkTimeout(ctx, send(ctx, func() error {
+		var err error
+		rows, err = c.conn.Query(ctx, query, args...)
+		return err
+	}))
+	logStatement(ctx, statement, start, err)
+	return rows, err
+}
+
+// retryRead runs fn, which sends a read-only statement, retrying every
+// transient failure.
+func (c *providerClient) retryRead(ctx context.Context, fn func() error) error {
+	return c.retry.do(ctx, isRetryable, fn)
+}
+
+// sendOnce runs fn, which sends a statement that may change state. When the
+// connection fails after such a statement was sent, it may already have been
+// applied, so fn is only retried when it was rejected without running. A
+// ping first retries the transient failures of the connection itself, such
+// as a stale pooled connection being reset.
+func (c *providerClient) sendOnce(ctx context.Context, fn func() error) error {
+	return c.send(ctx, isRejected, fn)
+}
+
+// sendDDL runs fn, which sends a DDL statement. Besides the failures
+// sendOnce retries, it retries lost Keeper sessions, which DDL recovers from.
+func (c *providerClient) sendDDL(ctx context.Context, fn func() error) error {
+	return c.send(ctx, isRetryableDDL, fn)
+}
+
+// send pings the server, retrying every transient failure, and then runs fn,
+// retrying the failures retryable accepts.
+func (c *providerClient) send(ctx context.Context, retryable func(error) bool, fn func() error) error {
+	if err := c.retry.do(ctx, isRetryable, func() error { return c.conn.Ping(ctx) }); err != nil {
+		return err
+	}
+	return c.retry.do(ctx, retryable, fn)
+}
+
+// Cluster returns the default cluster for DDL statements.
+func (c *providerClient) Cluster() string {
+	return c.cluster
+}
+
+// Server describes the connected server.
+func (c *providerClient) Server() *serverInfo {
+	return c.server
+}
+
+// providerRow reports errors of a row caused by the operation timeout as
+// such.
+type providerRow struct {
+	driver.Row
+	ctx context.Context
+}
+
+// Err returns the error of the query.
+func (r *providerRow) Err() error {
+	return checkTimeout(r.ctx, r.Row.Err())
+}
+
+// Scan copies the row into dest.
+func (r *providerRow) Scan(dest ...any) error {
+	return checkTimeout(r.ctx, r.Row.Scan(dest...))
+}
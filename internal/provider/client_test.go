@@ -0,0 +1,248 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// fakeClient is an in-memory clickhouseClient. It records the statements
+// passed to Exec and answers queries from canned results.
+type fakeClient struct {
+	cluster string
+	server  *serverInfo
+	results map[string]*fakeRows
+	execErr error
+	execs   []string
+	ddls    []string
+}
+
+var _ clickhouseClient = &fakeClient{}
+
+func (c *fakeClient) Exec(_ context.Context, query string, _ ...any) error {
+	c.execs = append(c.execs, query)
+	return c.execErr
+}
+
+func (c *fakeClient) ExecDDL(ctx context.Context, query string, args ...any) error {
+	c.ddls = append(c.ddls, query)
+	return c.Exec(ctx, query, args...)
+}
+
+func (c *fakeClient) Query(_ context.Context, query string, _ ...any) (driver.Rows, error) {
+	result, found := c.results[query]
+	if !found {
+		return nil, fmt.Errorf("unexpected query: %s", query)
+	}
+	return &fakeRows{columns: result.columns, values: result.values, index: -1}, nil
+}
+
+func (c *fakeClient) QueryRow(ctx context.Context, query string, args ...any) driver.Row {
+	rows, err := c.Query(ctx, query, args...)
+	if err != nil {
+		return &fakeRow{err: err}
+	}
+	return &fakeRow{rows: rows.(*fakeRows)}
+}
+
+func (c *fakeClient) Cluster() string {
+	return c.cluster
+}
+
+func (c *fakeClient) Server() *serverInfo {
+	return c.server
+}
+
+// fakeColumn is a column of a canned result.
+type fakeColumn struct {
+	name     string
+	typeName string
+	scanType reflect.Type
+}
+
+func (c fakeColumn) Name() string             { return c.name }
+func (c fakeColumn) Nullable() bool           { return false }
+func (c fakeColumn) ScanType() reflect.Type   { return c.scanType }
+func (c fakeColumn) DatabaseTypeName() string { return c.typeName }
+
+// fakeRows iterates over a canned result.
+type fakeRows struct {
+	columns []fakeColumn
+	values  [][]any
+	index   int
+}
+
+func (r *fakeRows) Next() bool {
+	r.index++
+	return r.index < len(r.values)
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	row := r.values[r.index]
+	if len(dest) != len(row) {
+		return fmt.Errorf("scan into %d destinations, row has %d values", len(dest), len(row))
+	}
+	for i, value := range row {
+		reflect.ValueOf(dest[i]).Elem().Set(reflect.ValueOf(value))
+	}
+	return nil
+}
+
+func (r *fakeRows) ScanStruct(any) error { return errors.New("not implemented") }
+func (r *fakeRows) Totals(...any) error  { return errors.New("not implemented") }
+func (r *fakeRows) Close() error         { return nil }
+func (r *fakeRows) Err() error           { return nil }
+
+func (r *fakeRows) ColumnTypes() []driver.ColumnType {
+	columnTypes := make([]driver.ColumnType, len(r.columns))
+	for i, column := range r.columns {
+		columnTypes[i] = column
+	}
+	return columnTypes
+}
+
+func (r *fakeRows) Columns() []string {
+	names := make([]string, len(r.columns))
+	for i, column := range r.columns {
+		names[i] = column.name
+	}
+	return names
+}
+
+// fakeRow is the first row of a canned result.
+type fakeRow struct {
+	rows *fakeRows
+	err  error
+}
+
+func (r *fakeRow) Err() error { return r.err }
+
+func (r *fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	if !r.rows.Next() {
+		return errors.New("no rows")
+	}
+	return r.rows.Scan(dest...)
+}
+
+func (r *fakeRow) ScanStruct(any) error { return errors.New("not implemented") }
+
+var stringType = reflect.TypeOf("")
+
+func TestDetectServerInfo(t *testing.T) {
+	client := &fakeClient{results: map[string]*fakeRows{
+		"SELECT version()": {
+			columns: []fakeColumn{{"version()", "String", stringType}},
+			values:  [][]any{{"24.8.4.13"}},
+		},
+		"SELECT name, value FROM system.build_options": {
+			columns: []fakeColumn{{"name", "String", stringType}, {"value", "String", stringType}},
+			values:  [][]any{{"USE_MONGODB", "0"}, {"USE_AWS_S3", "1"}},
+		},
+	}}
+
+	server, err := detectServerInfo(context.Background(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server.major != 24 || server.minor != 8 || server.patch != 4 {
+		t.Errorf("version = %d.%d.%d, want 24.8.4", server.major, server.minor, server.patch)
+	}
+	if server.supports(capMongoDBEngine) {
+		t.Error("MongoDB engine reported as supported without USE_MONGODB")
+	}
+	if !server.supports(capS3QueueEngine) {
+		t.Error("S3Queue engine reported as unsupported on 24.8 with USE_AWS_S3")
+	}
+}
+
+func TestSQLResourceScript(t *testing.T) {
+	client := &fakeClient{results: map[string]*fakeRows{
+		"SELECT name, rows FROM t": {
+			columns: []fakeColumn{{"name", "String", stringType}, {"rows", "UInt64", reflect.TypeOf(uint64(0))}},
+			values:  [][]any{{"a;b", uint64(42)}},
+		},
+	}}
+	r := &clickhouseSQLResource{client: client}
+	ctx := context.Background()
+
+	script := "CREATE TABLE t {on_cluster} (s String) ENGINE = Memory;\nINSERT INTO t VALUES ('a;b');"
+	if err := r.execScript(ctx, script, types.StringNull()); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"CREATE TABLE t  (s String) ENGINE = Memory", "INSERT INTO t VALUES ('a;b')"}
+	if !reflect.DeepEqual(client.execs, want) || len(client.ddls) != 0 {
+		t.Errorf("executed %q, as DDL %q, want %q", client.execs, client.ddls, want)
+	}
+
+	client.cluster = "main"
+	client.execs = nil
+	if err := r.execScript(ctx, script, types.StringNull()); err != nil {
+		t.Fatal(err)
+	}
+	wantDDL := "CREATE TABLE t ON CLUSTER `main` (s String) ENGINE = Memory"
+	if !reflect.DeepEqual(client.ddls, []string{wantDDL}) || len(client.execs) != 2 {
+		t.Errorf("executed %q, as DDL %q, want %q as DDL", client.execs, client.ddls, wantDDL)
+	}
+	client.cluster = ""
+
+	client.execErr = errors.New("boom")
+	if err := r.execScript(ctx, "SELECT 1; SELECT 2", types.StringNull()); err == nil {
+		t.Error("execScript did not return the statement error")
+	}
+	if len(client.execs) != 3 {
+		t.Errorf("execScript ran %d statements, want it to stop after the failing one", len(client.execs)-2)
+	}
+
+	row, found, err := r.readResult(ctx, "SELECT name, rows FROM t")
+	if err != nil || !found {
+		t.Fatalf("readResult() = %v, %v", found, err)
+	}
+	if *row["name"] != "a;b" || *row["rows"] != "42" {
+		t.Errorf("readResult() = name %q, rows %q", *row["name"], *row["rows"])
+	}
+}
+
+func TestOnClusterDefault(t *testing.T) {
+	for _, test := range []struct {
+		onCluster types.String
+		cluster   string
+		want      string
+	}{
+		{types.StringNull(), "", ""},
+		{types.StringNull(), "main", " ON CLUSTER `main`"},
+		{types.StringValue("other"), "main", " ON CLUSTER `other`"},
+		{types.StringValue("other"), "", " ON CLUSTER `other`"},
+	} {
+		if got := onClusterClause(test.onCluster, test.cluster); got != test.want {
+			t.Errorf("onClusterClause(%s, %q) = %q, want %q", test.onCluster, test.cluster, got, test.want)
+		}
+	}
+}
+
+func TestSameExpression(t *testing.T) {
+	for _, test := range []struct {
+		a, b string
+		want bool
+	}{
+		{"Decimal(10,2)", "Decimal(10, 2)", true},
+		{"tenant_id = currentUser()", "tenant_id=currentUser()", true},
+		{"name = 'a b'", "name='a b'", true},
+		{"name = 'a b'", "name = 'ab'", false},
+		{"concat(x, ' ')", "concat(x,'')", false},
+		{"`my col` + 1", "`mycol`+1", false},
+		{`x = 'it\'s a'`, `x='it\'s a'`, true},
+		{`x = 'it\'s a'`, `x='it\'sa'`, false},
+	} {
+		if got := sameExpression(test.a, test.b); got != test.want {
+			t.Errorf("sameExpression(%q, %q) = %t, want %t", test.a, test.b, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+)
+
+// jwtAuthMarker is the username that makes the server read the password of
+// the native protocol handshake as a JSON Web Token.
+const jwtAuthMarker = " JWT AUTHENTICATION "
+
+// readSecretFile reads a password or token from a file, such as a mounted
+// secret, without the trailing newline most tools write.
+func readSecretFile(name string) (string, error) {
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+	secret := strings.TrimRight(string(content), "\r\n")
+	if secret == "" {
+		return "", fmt.Errorf("%s is empty", name)
+	}
+	return secret, nil
+}
+
+// authOptions returns the credentials sent in the handshake. An access token
+// replaces the username and password. Without a password, a server reached
+// over TLS with a client certificate authenticates the user by the
+// certificate.
+func authOptions(username, password, accessToken string) clickhouse.Auth {
+	if accessToken != "" {
+		username, password = jwtAuthMarker, accessToken
+	}
+	return clickhouse.Auth{
+		Database: "default",
+		Username: username,
+		Password: password,
+	}
+}
+
+// newTLSConfig returns the TLS configuration of the connection. caFile
+// replaces the system roots used to verify the server, and certFile and
+// keyFile hold the client certificate presented to it.
+func newTLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%s holds no PEM encoded certificates", caFile)
+		}
+	}
+
+	if certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			return nil, errors.New("a client certificate needs both a certificate and a key file")
+		}
+		certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	return config, nil
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReadSecretFile(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "password")
+	if err := os.WriteFile(name, []byte("s3cr3t \n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if secret, err := readSecretFile(name); err != nil || secret != "s3cr3t " {
+		t.Errorf("readSecretFile() = %q, %v, want the content without the trailing newline", secret, err)
+	}
+
+	empty := filepath.Join(dir, "empty")
+	if err := os.WriteFile(empty, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readSecretFile(empty); err == nil {
+		t.Error("readSecretFile() of an empty file did not fail")
+	}
+	if _, err := readSecretFile(filepath.Join(dir, "missing")); err == nil {
+		t.Error("readSecretFile() of a missing file did not fail")
+	}
+}
+
+func TestAuthOptions(t *testing.T) {
+	auth := authOptions("alice", "pw", "")
+	if auth.Username != "alice" || auth.Password != "pw" {
+		t.Errorf("authOptions() = %+v", auth)
+	}
+	auth = authOptions("", "", "eyJhbGciOi")
+	if auth.Username != jwtAuthMarker || auth.Password != "eyJhbGciOi" {
+		t.Errorf("authOptions() with an access token = %+v", auth)
+	}
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key")
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "alice"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := newTLSConfig(certFile, certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.RootCAs == nil || len(config.Certificates) != 1 {
+		t.Errorf("newTLSConfig() = %+v, want custom roots and a client certificate", config)
+	}
+
+	if config, err := newTLSConfig("", "", ""); err != nil || config.RootCAs != nil || len(config.Certificates) != 0 {
+		t.Errorf("newTLSConfig() without files = %+v, %v, want the system roots", config, err)
+	}
+	if _, err := newTLSConfig("", certFile, ""); err == nil {
+		t.Error("newTLSConfig() accepted a client certificate without a key")
+	}
+	if _, err := newTLSConfig(keyFile, "", ""); err == nil {
+		t.Error("newTLSConfig() accepted a CA file without certificates")
+	}
+}
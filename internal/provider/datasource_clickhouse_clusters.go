@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &clickhouseClustersDataSource{}
+	_ datasource.DataSourceWithConfigure = &clickhouseClustersDataSource{}
+)
+
+// clickhouseClustersDataSource is the data source implementation.
+type clickhouseClustersDataSource struct {
+	client clickhouseClient
+}
+
+// clickhouseClustersDataSourceModel maps the data source schema data.
+type clickhouseClustersDataSourceModel struct {
+	Cluster  types.String                 `tfsdk:"cluster"`
+	Clusters []types.String               `tfsdk:"clusters"`
+	Hosts    []clickhouseClusterHostModel `tfsdk:"hosts"`
+}
+
+// clickhouseClusterHostModel maps a replica of a shard as described by
+// system.clusters.
+type clickhouseClusterHostModel struct {
+	Cluster               types.String `tfsdk:"cluster"`
+	ShardNum              types.Int64  `tfsdk:"shard_num"`
+	ShardWeight           types.Int64  `tfsdk:"shard_weight"`
+	ReplicaNum            types.Int64  `tfsdk:"replica_num"`
+	HostName              types.String `tfsdk:"host_name"`
+	HostAddress           types.String `tfsdk:"host_address"`
+	Port                  types.Int64  `tfsdk:"port"`
+	IsLocal               types.Bool   `tfsdk:"is_local"`
+	ErrorsCount           types.Int64  `tfsdk:"errors_count"`
+	EstimatedRecoveryTime types.Int64  `tfsdk:"estimated_recovery_time"`
+}
+
+// Metadata returns the data source type name.
+func (d *clickhouseClustersDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "clickhouse_clusters"
+}
+
+// Schema defines the schema for the data source.
+func (d *clickhouseClustersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the clusters known to the server and the hosts of their shards.",
+		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return this cluster.",
+			},
+			"clusters": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The names of the clusters.",
+			},
+			"hosts": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The hosts of every shard replica, ordered by cluster, shard and replica.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"cluster": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the cluster.",
+						},
+						"shard_num": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The 1-based shard number.",
+						},
+						"shard_weight": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The relative weight of the shard when writing data.",
+						},
+						"replica_num": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The 1-based replica number within the shard.",
+						},
+						"host_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The host name as configured.",
+						},
+						"host_address": schema.StringAttribute{
+							Computed:    true,
+							Description: "The IP address the host name resolved to.",
+						},
+						"port": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The native protocol port of the host.",
+						},
+						"is_local": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the host is the server being queried.",
+						},
+						"errors_count": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The number of times connecting to the host failed.",
+						},
+						"estimated_recovery_time": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Seconds until the error count is reset and the host is considered healthy.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read performs the read operation for the data source.
+func (d *clickhouseClustersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withOperation(ctx, "clickhouse_clusters", "read")
+
+	var state clickhouseClustersDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := "SELECT cluster, toInt64(shard_num), toInt64(shard_weight), toInt64(replica_num), host_name, host_address, " +
+		"toInt64(port), is_local, toInt64(errors_count), toInt64(estimated_recovery_time) FROM system.clusters"
+	var args []any
+	if !state.Cluster.IsNull() {
+		query += " WHERE cluster = ?"
+		args = append(args, state.Cluster.ValueString())
+	}
+	query += " ORDER BY cluster, shard_num, replica_num"
+
+	rows, err := d.client.Query(ctx, query, args...)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list clusters",
+			"An error occurred while listing the clusters: "+err.Error(),
+		)
+		return
+	}
+	defer rows.Close()
+
+	state.Clusters = []types.String{}
+	state.Hosts = []clickhouseClusterHostModel{}
+	for rows.Next() {
+		var (
+			cluster, hostName, hostAddress                                          string
+			shardNum, shardWeight, replicaNum, port, errorsCount, estimatedRecovery int64
+			isLocal                                                                 bool
+		)
+		if err := rows.Scan(&cluster, &shardNum, &shardWeight, &replicaNum, &hostName, &hostAddress,
+			&port, &isLocal, &errorsCount, &estimatedRecovery); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read cluster host",
+				"An error occurred while reading the cluster host: "+err.Error(),
+			)
+			return
+		}
+		if len(state.Clusters) == 0 || state.Clusters[len(state.Clusters)-1].ValueString() != cluster {
+			state.Clusters = append(state.Clusters, types.StringValue(cluster))
+		}
+		state.Hosts = append(state.Hosts, clickhouseClusterHostModel{
+			Cluster:               types.StringValue(cluster),
+			ShardNum:              types.Int64Value(shardNum),
+			ShardWeight:           types.Int64Value(shardWeight),
+			ReplicaNum:            types.Int64Value(replicaNum),
+			HostName:              types.StringValue(hostName),
+			HostAddress:           types.StringValue(hostAddress),
+			Port:                  types.Int64Value(port),
+			IsLocal:               types.BoolValue(isLocal),
+			ErrorsCount:           types.Int64Value(errorsCount),
+			EstimatedRecoveryTime: types.Int64Value(estimatedRecovery),
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list clusters",
+			"An error occurred while listing the clusters: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure configures the data source with the provider data.
+func (d *clickhouseClustersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(clickhouseClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected clickhouseClient, got something else",
+		)
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &clickhouseColumnsDataSource{}
+	_ datasource.DataSourceWithConfigure = &clickhouseColumnsDataSource{}
+)
+
+// clickhouseColumnsDataSource is the data source implementation.
+type clickhouseColumnsDataSource struct {
+	client clickhouseClient
+}
+
+// clickhouseColumnsDataSourceModel maps the data source schema data.
+type clickhouseColumnsDataSourceModel struct {
+	Database types.String                `tfsdk:"database"`
+	Table    types.String                `tfsdk:"table"`
+	Columns  []clickhouseColumnInfoModel `tfsdk:"columns"`
+}
+
+// clickhouseColumnInfoModel maps a column as described by system.columns.
+type clickhouseColumnInfoModel struct {
+	Name                  types.String `tfsdk:"name"`
+	Type                  types.String `tfsdk:"type"`
+	Position              types.Int64  `tfsdk:"position"`
+	DefaultKind           types.String `tfsdk:"default_kind"`
+	DefaultExpression     types.String `tfsdk:"default_expression"`
+	CompressionCodec      types.String `tfsdk:"compression_codec"`
+	DataCompressedBytes   types.Int64  `tfsdk:"data_compressed_bytes"`
+	DataUncompressedBytes types.Int64  `tfsdk:"data_uncompressed_bytes"`
+	MarksBytes            types.Int64  `tfsdk:"marks_bytes"`
+	IsInPartitionKey      types.Bool   `tfsdk:"is_in_partition_key"`
+	IsInSortingKey        types.Bool   `tfsdk:"is_in_sorting_key"`
+	IsInPrimaryKey        types.Bool   `tfsdk:"is_in_primary_key"`
+	Comment               types.String `tfsdk:"comment"`
+}
+
+// Metadata returns the data source type name.
+func (d *clickhouseColumnsDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "clickhouse_columns"
+}
+
+// Schema defines the schema for the data source.
+func (d *clickhouseColumnsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the columns of a ClickHouse table.",
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Required:    true,
+				Description: "The database of the table.",
+			},
+			"table": schema.StringAttribute{
+				Required:    true,
+				Description: "The table to list columns from.",
+			},
+			"columns": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "List of columns in table order.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the column.",
+						},
+						"type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The data type of the column.",
+						},
+						"position": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The 1-based position of the column in the table.",
+						},
+						"default_kind": schema.StringAttribute{
+							Computed:    true,
+							Description: "The kind of default expression: DEFAULT, MATERIALIZED, ALIAS or EPHEMERAL. Empty when there is none.",
+						},
+						"default_expression": schema.StringAttribute{
+							Computed:    true,
+							Description: "The default expression of the column.",
+						},
+						"compression_codec": schema.StringAttribute{
+							Computed:    true,
+							Description: "The compression codec of the column.",
+						},
+						"data_compressed_bytes": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The size of the compressed column data.",
+						},
+						"data_uncompressed_bytes": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The size of the uncompressed column data.",
+						},
+						"marks_bytes": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The size of the column marks.",
+						},
+						"is_in_partition_key": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the column is part of the partition key.",
+						},
+						"is_in_sorting_key": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the column is part of the sorting key.",
+						},
+						"is_in_primary_key": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the column is part of the primary key.",
+						},
+						"comment": schema.StringAttribute{
+							Computed:    true,
+							Description: "The comment of the column.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read performs the read operation for the data source.
+func (d *clickhouseColumnsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withOperation(ctx, "clickhouse_columns", "read")
+
+	var state clickhouseColumnsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rows, err := d.client.Query(ctx, "SELECT name, type, position, default_kind, default_expression, compression_codec, "+
+		"data_compressed_bytes, data_uncompressed_bytes, marks_bytes, is_in_partition_key, is_in_sorting_key, is_in_primary_key, comment "+
+		"FROM system.columns WHERE database = ? AND table = ? ORDER BY position",
+		state.Database.ValueString(), state.Table.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list columns",
+			"An error occurred while listing the columns: "+err.Error(),
+		)
+		return
+	}
+	defer rows.Close()
+
+	state.Columns = []clickhouseColumnInfoModel{}
+	for rows.Next() {
+		var (
+			name, columnType, defaultKind, defaultExpression, codec, comment string
+			position, compressed, uncompressed, marks                        uint64
+			inPartitionKey, inSortingKey, inPrimaryKey                       uint8
+		)
+		if err := rows.Scan(&name, &columnType, &position, &defaultKind, &defaultExpression, &codec,
+			&compressed, &uncompressed, &marks, &inPartitionKey, &inSortingKey, &inPrimaryKey, &comment); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read column",
+				"An error occurred while reading the column: "+err.Error(),
+			)
+			return
+		}
+		state.Columns = append(state.Columns, clickhouseColumnInfoModel{
+			Name:                  types.StringValue(name),
+			Type:                  types.StringValue(columnType),
+			Position:              types.Int64Value(int64(position)),
+			DefaultKind:           types.StringValue(defaultKind),
+			DefaultExpression:     types.StringValue(defaultExpression),
+			CompressionCodec:      types.StringValue(codec),
+			DataCompressedBytes:   types.Int64Value(int64(compressed)),
+			DataUncompressedBytes: types.Int64Value(int64(uncompressed)),
+			MarksBytes:            types.Int64Value(int64(marks)),
+			IsInPartitionKey:      types.BoolValue(inPartitionKey == 1),
+			IsInSortingKey:        types.BoolValue(inSortingKey == 1),
+			IsInPrimaryKey:        types.BoolValue(inPrimaryKey == 1),
+			Comment:               types.StringValue(comment),
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list columns",
+			"An error occurred while listing the columns: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure configures the data source with the provider data.
+func (d *clickhouseColumnsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(clickhouseClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected clickhouseClient, got something else",
+		)
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &clickhouseDatabaseDataSource{}
+	_ datasource.DataSourceWithConfigure = &clickhouseDatabaseDataSource{}
+)
+
+// clickhouseDatabaseDataSource is the data source implementation.
+type clickhouseDatabaseDataSource struct {
+	client clickhouseClient
+}
+
+// Metadata returns the data source type name.
+func (d *clickhouseDatabaseDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "clickhouse_database"
+}
+
+// Schema defines the schema for the data source.
+func (d *clickhouseDatabaseDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := databaseAttributes()
+	attributes["name"] = schema.StringAttribute{
+		Required:    true,
+		Description: "The name of the database to look up.",
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Looks up a single ClickHouse database by name.",
+		Attributes:  attributes,
+	}
+}
+
+// Read performs the read operation for the data source.
+func (d *clickhouseDatabaseDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withOperation(ctx, "clickhouse_database", "read")
+
+	var state clickhouseDatabaseModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := state.Name.ValueString()
+	databases, err := readDatabases(ctx, d.client, []string{"d.name = ?"}, name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read database",
+			"An error occurred while reading the database: "+err.Error(),
+		)
+		return
+	}
+
+	if len(databases) == 0 {
+		resp.Diagnostics.AddError(
+			"Database not found",
+			"The ClickHouse database "+name+" does not exist.",
+		)
+		return
+	}
+	state = databases[0]
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure configures the data source with the provider data.
+func (d *clickhouseDatabaseDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(clickhouseClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected clickhouseClient, got something else",
+		)
+		return
+	}
+
+	d.client = client
+}
@@ -2,8 +2,8 @@ package provider
 
 import (
 	"context"
+	"strings"
 
-	"github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -17,12 +17,74 @@ var (
 
 // clickhouseDatabasesDataSource is the data source implementation.
 type clickhouseDatabasesDataSource struct {
-	client clickhouse.Conn
+	client clickhouseClient
 }
 
 // clickhouseDatabasesDataSourceModel maps the data source schema data.
 type clickhouseDatabasesDataSourceModel struct {
-	Databases []types.String `tfsdk:"databases"`
+	ExcludeSystem types.Bool                `tfsdk:"exclude_system"`
+	NameRegex     types.String              `tfsdk:"name_regex"`
+	Databases     []clickhouseDatabaseModel `tfsdk:"databases"`
+}
+
+// clickhouseDatabaseModel maps a database as described by system.databases,
+// with its size aggregated from the active parts of its tables.
+type clickhouseDatabaseModel struct {
+	Name        types.String `tfsdk:"name"`
+	Engine      types.String `tfsdk:"engine"`
+	EngineFull  types.String `tfsdk:"engine_full"`
+	UUID        types.String `tfsdk:"uuid"`
+	DataPath    types.String `tfsdk:"data_path"`
+	Comment     types.String `tfsdk:"comment"`
+	BytesOnDisk types.Int64  `tfsdk:"bytes_on_disk"`
+	Rows        types.Int64  `tfsdk:"rows"`
+	Parts       types.Int64  `tfsdk:"parts"`
+}
+
+// systemDatabases are the databases the server creates for itself.
+var systemDatabases = []string{"system", "INFORMATION_SCHEMA", "information_schema"}
+
+// databaseAttributes describes the attributes of a database read from
+// system.databases.
+func databaseAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			Computed:    true,
+			Description: "The name of the database.",
+		},
+		"engine": schema.StringAttribute{
+			Computed:    true,
+			Description: "The database engine, e.g. Atomic or Replicated.",
+		},
+		"engine_full": schema.StringAttribute{
+			Computed:    true,
+			Description: "The database engine with its arguments.",
+		},
+		"uuid": schema.StringAttribute{
+			Computed:    true,
+			Description: "The UUID of the database.",
+		},
+		"data_path": schema.StringAttribute{
+			Computed:    true,
+			Description: "The path of the database data on disk.",
+		},
+		"comment": schema.StringAttribute{
+			Computed:    true,
+			Description: "The comment of the database.",
+		},
+		"bytes_on_disk": schema.Int64Attribute{
+			Computed:    true,
+			Description: "The size of the active parts of all tables in the database.",
+		},
+		"rows": schema.Int64Attribute{
+			Computed:    true,
+			Description: "The number of rows in the active parts of all tables in the database.",
+		},
+		"parts": schema.Int64Attribute{
+			Computed:    true,
+			Description: "The number of active parts of all tables in the database.",
+		},
+	}
 }
 
 // Metadata returns the data source type name.
@@ -34,20 +96,91 @@ func (d *clickhouseDatabasesDataSource) Metadata(_ context.Context, _ datasource
 func (d *clickhouseDatabasesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
-			"databases": schema.ListAttribute{
-				ElementType: types.StringType,
+			"exclude_system": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Leave out the system and INFORMATION_SCHEMA databases.",
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return databases whose name matches this re2 regular expression.",
+			},
+			"databases": schema.ListNestedAttribute{
 				Computed:    true,
 				Description: "List of databases in the ClickHouse server.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: databaseAttributes(),
+				},
 			},
 		},
 	}
 }
 
+// readDatabases lists the databases from system.databases matching the given
+// conditions, ordered by name.
+func readDatabases(ctx context.Context, client clickhouseClient, conditions []string, args ...any) ([]clickhouseDatabaseModel, error) {
+	query := "SELECT d.name, d.engine, d.engine_full, toString(d.uuid), d.data_path, d.comment, " +
+		"p.bytes_on_disk, p.rows, p.parts FROM system.databases AS d " +
+		"LEFT JOIN (SELECT database, sum(bytes_on_disk) AS bytes_on_disk, sum(rows) AS rows, count() AS parts " +
+		"FROM system.parts WHERE active GROUP BY database) AS p ON p.database = d.name"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY d.name"
+
+	rows, err := client.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	databases := []clickhouseDatabaseModel{}
+	for rows.Next() {
+		var (
+			name, engine, engineFull, uuid, dataPath, comment string
+			bytesOnDisk, rowCount, parts                      uint64
+		)
+		if err := rows.Scan(&name, &engine, &engineFull, &uuid, &dataPath, &comment, &bytesOnDisk, &rowCount, &parts); err != nil {
+			return nil, err
+		}
+
+		databases = append(databases, clickhouseDatabaseModel{
+			Name:        types.StringValue(name),
+			Engine:      types.StringValue(engine),
+			EngineFull:  types.StringValue(engineFull),
+			UUID:        types.StringValue(uuid),
+			DataPath:    types.StringValue(dataPath),
+			Comment:     types.StringValue(comment),
+			BytesOnDisk: types.Int64Value(int64(bytesOnDisk)),
+			Rows:        types.Int64Value(int64(rowCount)),
+			Parts:       types.Int64Value(int64(parts)),
+		})
+	}
+	return databases, rows.Err()
+}
+
 // Read performs the read operation for the data source.
 func (d *clickhouseDatabasesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withOperation(ctx, "clickhouse_databases", "read")
+
 	var state clickhouseDatabasesDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var conditions []string
+	var args []any
+	if state.ExcludeSystem.ValueBool() {
+		conditions = append(conditions, "NOT has(?, d.name)")
+		args = append(args, systemDatabases)
+	}
+	if !state.NameRegex.IsNull() {
+		conditions = append(conditions, "match(d.name, ?)")
+		args = append(args, state.NameRegex.ValueString())
+	}
 
-	rows, err := d.client.Query(ctx, "SHOW DATABASES")
+	databases, err := readDatabases(ctx, d.client, conditions, args...)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to list databases",
@@ -55,24 +188,10 @@ func (d *clickhouseDatabasesDataSource) Read(ctx context.Context, req datasource
 		)
 		return
 	}
-	defer rows.Close()
-
-	var databases []types.String
-	for rows.Next() {
-		var database string
-		if err := rows.Scan(&database); err != nil {
-			resp.Diagnostics.AddError(
-				"Unable to read database name",
-				"An error occurred while reading the database name: "+err.Error(),
-			)
-			return
-		}
-		databases = append(databases, types.StringValue(database))
-	}
 
 	state.Databases = databases
 
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 }
 
@@ -82,11 +201,11 @@ func (d *clickhouseDatabasesDataSource) Configure(ctx context.Context, req datas
 		return
 	}
 
-	client, ok := req.ProviderData.(clickhouse.Conn)
+	client, ok := req.ProviderData.(clickhouseClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			"Expected clickhouse.Conn, got something else",
+			"Expected clickhouseClient, got something else",
 		)
 		return
 	}
@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestDatabasesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+data "clickhouse_databases" "test" {
+  exclude_system = true
+  name_regex     = "^default$"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.clickhouse_databases.test", "databases.#", "1"),
+					resource.TestCheckResourceAttr("data.clickhouse_databases.test", "databases.0.name", "default"),
+					resource.TestCheckResourceAttrSet("data.clickhouse_databases.test", "databases.0.engine"),
+					resource.TestCheckResourceAttrSet("data.clickhouse_databases.test", "databases.0.bytes_on_disk"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &clickhouseDisksDataSource{}
+	_ datasource.DataSourceWithConfigure = &clickhouseDisksDataSource{}
+)
+
+// clickhouseDisksDataSource is the data source implementation.
+type clickhouseDisksDataSource struct {
+	client clickhouseClient
+}
+
+// clickhouseDisksDataSourceModel maps the data source schema data.
+type clickhouseDisksDataSourceModel struct {
+	Disks []clickhouseDiskModel `tfsdk:"disks"`
+}
+
+// clickhouseDiskModel maps a disk as described by system.disks.
+type clickhouseDiskModel struct {
+	Name          types.String `tfsdk:"name"`
+	Type          types.String `tfsdk:"type"`
+	Path          types.String `tfsdk:"path"`
+	FreeSpace     types.Int64  `tfsdk:"free_space"`
+	TotalSpace    types.Int64  `tfsdk:"total_space"`
+	KeepFreeSpace types.Int64  `tfsdk:"keep_free_space"`
+}
+
+// Metadata returns the data source type name.
+func (d *clickhouseDisksDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "clickhouse_disks"
+}
+
+// Schema defines the schema for the data source.
+func (d *clickhouseDisksDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the disks configured on the server.",
+		Attributes: map[string]schema.Attribute{
+			"disks": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "List of disks ordered by name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the disk.",
+						},
+						"type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The disk type, e.g. Local or S3.",
+						},
+						"path": schema.StringAttribute{
+							Computed:    true,
+							Description: "The mount path of the disk.",
+						},
+						"free_space": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Free space on the disk in bytes.",
+						},
+						"total_space": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Total space of the disk in bytes.",
+						},
+						"keep_free_space": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Space in bytes the server keeps free on the disk.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read performs the read operation for the data source.
+func (d *clickhouseDisksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withOperation(ctx, "clickhouse_disks", "read")
+
+	var state clickhouseDisksDataSourceModel
+
+	rows, err := d.client.Query(ctx, "SELECT name, toString(type), path, toInt64(free_space), toInt64(total_space), "+
+		"toInt64(keep_free_space) FROM system.disks ORDER BY name")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list disks",
+			"An error occurred while listing the disks: "+err.Error(),
+		)
+		return
+	}
+	defer rows.Close()
+
+	state.Disks = []clickhouseDiskModel{}
+	for rows.Next() {
+		var (
+			name, diskType, path                 string
+			freeSpace, totalSpace, keepFreeSpace int64
+		)
+		if err := rows.Scan(&name, &diskType, &path, &freeSpace, &totalSpace, &keepFreeSpace); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read disk",
+				"An error occurred while reading the disk: "+err.Error(),
+			)
+			return
+		}
+		state.Disks = append(state.Disks, clickhouseDiskModel{
+			Name:          types.StringValue(name),
+			Type:          types.StringValue(diskType),
+			Path:          types.StringValue(path),
+			FreeSpace:     types.Int64Value(freeSpace),
+			TotalSpace:    types.Int64Value(totalSpace),
+			KeepFreeSpace: types.Int64Value(keepFreeSpace),
+		})
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure configures the data source with the provider data.
+func (d *clickhouseDisksDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(clickhouseClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected clickhouseClient, got something else",
+		)
+		return
+	}
+
+	d.client = client
+}
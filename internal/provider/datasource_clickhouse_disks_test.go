@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestTopologyDataSources(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+data "clickhouse_disks" "test" {}
+
+data "clickhouse_storage_policies" "test" {}
+
+data "clickhouse_clusters" "test" {}
+
+data "clickhouse_macros" "test" {}
+
+data "clickhouse_replicas" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.clickhouse_disks.test", "disks.0.name", "default"),
+					resource.TestCheckResourceAttr("data.clickhouse_storage_policies.test", "policies.0.name", "default"),
+					resource.TestCheckResourceAttr("data.clickhouse_storage_policies.test", "policies.0.volumes.0.disks.0", "default"),
+					resource.TestCheckResourceAttrSet("data.clickhouse_clusters.test", "hosts.#"),
+					resource.TestCheckResourceAttrSet("data.clickhouse_macros.test", "macros.%"),
+					resource.TestCheckResourceAttrSet("data.clickhouse_replicas.test", "replicas.#"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,382 @@
+package provider
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &clickhouseGrantsDataSource{}
+	_ datasource.DataSourceWithConfigure = &clickhouseGrantsDataSource{}
+)
+
+// clickhouseGrantsDataSource is the data source implementation.
+type clickhouseGrantsDataSource struct {
+	client clickhouseClient
+}
+
+// clickhouseGrantsDataSourceModel maps the data source schema data.
+type clickhouseGrantsDataSourceModel struct {
+	Grantee         types.String                  `tfsdk:"grantee"`
+	Database        types.String                  `tfsdk:"database"`
+	AccessType      types.String                  `tfsdk:"access_type"`
+	Grants          []clickhouseGranteeGrantModel `tfsdk:"grants"`
+	EffectiveGrants []clickhouseGranteeGrantModel `tfsdk:"effective_grants"`
+}
+
+// clickhouseGranteeGrantModel maps a privilege held by a user or role.
+type clickhouseGranteeGrantModel struct {
+	Grantee         types.String `tfsdk:"grantee"`
+	GranteeType     types.String `tfsdk:"grantee_type"`
+	AccessType      types.String `tfsdk:"access_type"`
+	Database        types.String `tfsdk:"database"`
+	Table           types.String `tfsdk:"table"`
+	Column          types.String `tfsdk:"column"`
+	IsPartialRevoke types.Bool   `tfsdk:"is_partial_revoke"`
+	GrantOption     types.Bool   `tfsdk:"grant_option"`
+	Via             types.String `tfsdk:"via"`
+}
+
+// principal identifies a user or a role. Users and roles live in separate
+// namespaces, so the name alone is not enough.
+type principal struct {
+	name   string
+	isRole bool
+}
+
+// typeName returns the grantee_type of the principal.
+func (p principal) typeName() string {
+	if p.isRole {
+		return "role"
+	}
+	return "user"
+}
+
+// grantRow is a row of system.grants.
+type grantRow struct {
+	grantee         principal
+	accessType      string
+	database        *string
+	table           *string
+	column          *string
+	isPartialRevoke bool
+	grantOption     bool
+}
+
+// effectiveGrant is a privilege held by a principal, either directly or via
+// an inherited role.
+type effectiveGrant struct {
+	holder principal
+	grant  grantRow
+	via    string
+}
+
+// resolveEffectiveGrants expands the privileges of every principal through
+// the roles granted to it, following role inheritance transitively. Grants
+// held directly have an empty via; inherited ones name the role they come
+// from.
+func resolveEffectiveGrants(grants []grantRow, roleGrants map[principal][]string) []effectiveGrant {
+	byGrantee := map[principal][]grantRow{}
+	principals := map[principal]bool{}
+	for _, grant := range grants {
+		byGrantee[grant.grantee] = append(byGrantee[grant.grantee], grant)
+		principals[grant.grantee] = true
+	}
+	for grantee := range roleGrants {
+		principals[grantee] = true
+	}
+
+	holders := make([]principal, 0, len(principals))
+	for holder := range principals {
+		holders = append(holders, holder)
+	}
+	sort.Slice(holders, func(i, j int) bool {
+		if holders[i].isRole != holders[j].isRole {
+			return !holders[i].isRole
+		}
+		return holders[i].name < holders[j].name
+	})
+
+	var effective []effectiveGrant
+	for _, holder := range holders {
+		for _, grant := range byGrantee[holder] {
+			effective = append(effective, effectiveGrant{holder: holder, grant: grant})
+		}
+
+		// A role reached again through a cycle must not inherit from itself.
+		visited := map[string]bool{}
+		if holder.isRole {
+			visited[holder.name] = true
+		}
+		queue := append([]string(nil), roleGrants[holder]...)
+		for len(queue) > 0 {
+			role := queue[0]
+			queue = queue[1:]
+			if visited[role] {
+				continue
+			}
+			visited[role] = true
+
+			rolePrincipal := principal{name: role, isRole: true}
+			for _, grant := range byGrantee[rolePrincipal] {
+				effective = append(effective, effectiveGrant{holder: holder, grant: grant, via: role})
+			}
+			queue = append(queue, roleGrants[rolePrincipal]...)
+		}
+	}
+	return effective
+}
+
+// Metadata returns the data source type name.
+func (d *clickhouseGrantsDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "clickhouse_grants"
+}
+
+// grantRowAttributes describes a privilege held by a user or role.
+func grantRowAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"grantee": schema.StringAttribute{
+			Computed:    true,
+			Description: "The user or role holding the privilege.",
+		},
+		"grantee_type": schema.StringAttribute{
+			Computed:    true,
+			Description: "Whether the grantee is a user or a role.",
+		},
+		"access_type": schema.StringAttribute{
+			Computed:    true,
+			Description: "The privilege, e.g. SELECT or ALL.",
+		},
+		"database": schema.StringAttribute{
+			Computed:    true,
+			Description: "The database the privilege applies to. Null for global privileges.",
+		},
+		"table": schema.StringAttribute{
+			Computed:    true,
+			Description: "The table the privilege applies to. Null for database or global privileges.",
+		},
+		"column": schema.StringAttribute{
+			Computed:    true,
+			Description: "The column the privilege applies to. Null for table or wider privileges.",
+		},
+		"is_partial_revoke": schema.BoolAttribute{
+			Computed:    true,
+			Description: "Whether this entry revokes part of a wider privilege.",
+		},
+		"grant_option": schema.BoolAttribute{
+			Computed:    true,
+			Description: "Whether the privilege was granted WITH GRANT OPTION.",
+		},
+		"via": schema.StringAttribute{
+			Computed:    true,
+			Description: "The role the privilege is inherited from. Null when it is granted directly.",
+		},
+	}
+}
+
+// Schema defines the schema for the data source.
+func (d *clickhouseGrantsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists privileges from system.grants and resolves the privileges every user and role " +
+			"effectively holds through role inheritance.",
+		Attributes: map[string]schema.Attribute{
+			"grantee": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return privileges held by this user or role.",
+			},
+			"database": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return privileges on this database. Global privileges have no database and are not matched.",
+			},
+			"access_type": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return privileges of this type, e.g. ALL.",
+			},
+			"grants": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The privileges granted directly.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: grantRowAttributes(),
+				},
+			},
+			"effective_grants": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The privileges held directly or through granted roles, including roles granted to roles.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: grantRowAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// Read performs the read operation for the data source.
+func (d *clickhouseGrantsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withOperation(ctx, "clickhouse_grants", "read")
+
+	var state clickhouseGrantsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	grants, err := d.readAllGrants(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list grants",
+			"An error occurred while listing the grants: "+err.Error(),
+		)
+		return
+	}
+
+	roleGrants, err := d.readAllRoleGrants(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list role grants",
+			"An error occurred while listing the role grants: "+err.Error(),
+		)
+		return
+	}
+
+	state.Grants = []clickhouseGranteeGrantModel{}
+	for _, grant := range grants {
+		if state.matches(grant.grantee, grant) {
+			state.Grants = append(state.Grants, granteeGrantValue(grant.grantee, grant, ""))
+		}
+	}
+
+	state.EffectiveGrants = []clickhouseGranteeGrantModel{}
+	for _, effective := range resolveEffectiveGrants(grants, roleGrants) {
+		if state.matches(effective.holder, effective.grant) {
+			state.EffectiveGrants = append(state.EffectiveGrants, granteeGrantValue(effective.holder, effective.grant, effective.via))
+		}
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// matches reports whether a privilege held by holder passes the filters.
+func (m *clickhouseGrantsDataSourceModel) matches(holder principal, grant grantRow) bool {
+	if !m.Grantee.IsNull() && holder.name != m.Grantee.ValueString() {
+		return false
+	}
+	if !m.Database.IsNull() && (grant.database == nil || *grant.database != m.Database.ValueString()) {
+		return false
+	}
+	if !m.AccessType.IsNull() && grant.accessType != m.AccessType.ValueString() {
+		return false
+	}
+	return true
+}
+
+// granteeGrantValue converts a privilege held by holder into its model.
+func granteeGrantValue(holder principal, grant grantRow, via string) clickhouseGranteeGrantModel {
+	viaValue := types.StringNull()
+	if via != "" {
+		viaValue = types.StringValue(via)
+	}
+	return clickhouseGranteeGrantModel{
+		Grantee:         types.StringValue(holder.name),
+		GranteeType:     types.StringValue(holder.typeName()),
+		AccessType:      types.StringValue(grant.accessType),
+		Database:        stringValueOrNull(grant.database),
+		Table:           stringValueOrNull(grant.table),
+		Column:          stringValueOrNull(grant.column),
+		IsPartialRevoke: types.BoolValue(grant.isPartialRevoke),
+		GrantOption:     types.BoolValue(grant.grantOption),
+		Via:             viaValue,
+	}
+}
+
+// readAllGrants reads every row of system.grants.
+func (d *clickhouseGrantsDataSource) readAllGrants(ctx context.Context) ([]grantRow, error) {
+	rows, err := d.client.Query(ctx, "SELECT user_name, role_name, toString(access_type), database, table, column, "+
+		"is_partial_revoke, grant_option FROM system.grants ORDER BY access_type, database, table, column")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var grants []grantRow
+	for rows.Next() {
+		var (
+			userName, roleName, database, table, column *string
+			accessType                                  string
+			isPartialRevoke, grantOption                uint8
+		)
+		if err := rows.Scan(&userName, &roleName, &accessType, &database, &table, &column, &isPartialRevoke, &grantOption); err != nil {
+			return nil, err
+		}
+		grants = append(grants, grantRow{
+			grantee:         granteePrincipal(userName, roleName),
+			accessType:      accessType,
+			database:        database,
+			table:           table,
+			column:          column,
+			isPartialRevoke: isPartialRevoke == 1,
+			grantOption:     grantOption == 1,
+		})
+	}
+	return grants, rows.Err()
+}
+
+// readAllRoleGrants reads system.role_grants as the roles granted to each
+// user and role.
+func (d *clickhouseGrantsDataSource) readAllRoleGrants(ctx context.Context) (map[principal][]string, error) {
+	rows, err := d.client.Query(ctx, "SELECT user_name, role_name, granted_role_name FROM system.role_grants ORDER BY granted_role_name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roleGrants := map[principal][]string{}
+	for rows.Next() {
+		var (
+			userName, roleName *string
+			grantedRole        string
+		)
+		if err := rows.Scan(&userName, &roleName, &grantedRole); err != nil {
+			return nil, err
+		}
+		grantee := granteePrincipal(userName, roleName)
+		roleGrants[grantee] = append(roleGrants[grantee], grantedRole)
+	}
+	return roleGrants, rows.Err()
+}
+
+// granteePrincipal builds the principal from the user_name and role_name
+// columns, exactly one of which is set.
+func granteePrincipal(userName, roleName *string) principal {
+	if userName != nil {
+		return principal{name: *userName}
+	}
+	if roleName != nil {
+		return principal{name: *roleName, isRole: true}
+	}
+	return principal{}
+}
+
+// Configure configures the data source with the provider data.
+func (d *clickhouseGrantsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(clickhouseClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected clickhouseClient, got something else",
+		)
+		return
+	}
+
+	d.client = client
+}
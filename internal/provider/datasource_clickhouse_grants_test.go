@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestGrantsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+data "clickhouse_grants" "test" {
+  grantee     = "default"
+  access_type = "ALL"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.clickhouse_grants.test", "grants.#"),
+					resource.TestCheckResourceAttrSet("data.clickhouse_grants.test", "effective_grants.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestResolveEffectiveGrants(t *testing.T) {
+	analytics := "analytics"
+	grants := []grantRow{
+		{grantee: principal{name: "reader", isRole: true}, accessType: "SELECT", database: &analytics},
+		{grantee: principal{name: "admin", isRole: true}, accessType: "ALL"},
+		{grantee: principal{name: "alice"}, accessType: "SHOW DATABASES"},
+	}
+	roleGrants := map[principal][]string{
+		{name: "alice"}:                {"admin"},
+		{name: "admin", isRole: true}:  {"reader"},
+		{name: "reader", isRole: true}: {"admin"},
+		{name: "bob"}:                  {"reader"},
+	}
+
+	got := map[string][]string{}
+	for _, effective := range resolveEffectiveGrants(grants, roleGrants) {
+		key := effective.holder.typeName() + ":" + effective.holder.name
+		got[key] = append(got[key], effective.grant.accessType+"/"+effective.via)
+	}
+
+	want := map[string][]string{
+		"user:alice":  {"SHOW DATABASES/", "ALL/admin", "SELECT/reader"},
+		"user:bob":    {"SELECT/reader", "ALL/admin"},
+		"role:admin":  {"ALL/", "SELECT/reader"},
+		"role:reader": {"SELECT/", "ALL/admin"},
+	}
+	for key, grants := range want {
+		if len(got[key]) != len(grants) {
+			t.Fatalf("%s: got %v, want %v", key, got[key], grants)
+		}
+		for i := range grants {
+			if got[key][i] != grants[i] {
+				t.Errorf("%s: got %v, want %v", key, got[key], grants)
+			}
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("got grants for %d principals, want %d", len(got), len(want))
+	}
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &clickhouseMacrosDataSource{}
+	_ datasource.DataSourceWithConfigure = &clickhouseMacrosDataSource{}
+)
+
+// clickhouseMacrosDataSource is the data source implementation.
+type clickhouseMacrosDataSource struct {
+	client clickhouseClient
+}
+
+// clickhouseMacrosDataSourceModel maps the data source schema data.
+type clickhouseMacrosDataSourceModel struct {
+	Macros map[string]types.String `tfsdk:"macros"`
+}
+
+// Metadata returns the data source type name.
+func (d *clickhouseMacrosDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "clickhouse_macros"
+}
+
+// Schema defines the schema for the data source.
+func (d *clickhouseMacrosDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the macros of the server, such as shard and replica.",
+		Attributes: map[string]schema.Attribute{
+			"macros": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The substitution of every macro, keyed by macro name.",
+			},
+		},
+	}
+}
+
+// Read performs the read operation for the data source.
+func (d *clickhouseMacrosDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withOperation(ctx, "clickhouse_macros", "read")
+
+	var state clickhouseMacrosDataSourceModel
+
+	rows, err := d.client.Query(ctx, "SELECT macro, substitution FROM system.macros")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list macros",
+			"An error occurred while listing the macros: "+err.Error(),
+		)
+		return
+	}
+	defer rows.Close()
+
+	state.Macros = map[string]types.String{}
+	for rows.Next() {
+		var macro, substitution string
+		if err := rows.Scan(&macro, &substitution); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read macro",
+				"An error occurred while reading the macro: "+err.Error(),
+			)
+			return
+		}
+		state.Macros[macro] = types.StringValue(substitution)
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure configures the data source with the provider data.
+func (d *clickhouseMacrosDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(clickhouseClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected clickhouseClient, got something else",
+		)
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &clickhouseQueryDataSource{}
+	_ datasource.DataSourceWithConfigure = &clickhouseQueryDataSource{}
+)
+
+// clickhouseQueryDataSource is the data source implementation.
+type clickhouseQueryDataSource struct {
+	client clickhouseClient
+}
+
+// clickhouseQueryDataSourceModel maps the data source schema data.
+type clickhouseQueryDataSourceModel struct {
+	Query      types.String             `tfsdk:"query"`
+	Parameters types.Map                `tfsdk:"parameters"`
+	Rows       []types.Map              `tfsdk:"rows"`
+	Columns    []clickhouseColumnResult `tfsdk:"columns"`
+}
+
+// clickhouseColumnResult maps a column of a query result.
+type clickhouseColumnResult struct {
+	Name types.String `tfsdk:"name"`
+	Type types.String `tfsdk:"type"`
+}
+
+// Metadata returns the data source type name.
+func (d *clickhouseQueryDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "clickhouse_query"
+}
+
+// Schema defines the schema for the data source.
+func (d *clickhouseQueryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Runs a read-only query and returns its result rows. The query runs with readonly=1.",
+		Attributes: map[string]schema.Attribute{
+			"query": schema.StringAttribute{
+				Required:    true,
+				Description: "The SELECT query to run. Parameters are referenced as {name:Type}.",
+			},
+			"parameters": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Values bound to the query parameters, keyed by parameter name.",
+			},
+			"rows": schema.ListAttribute{
+				ElementType: types.MapType{ElemType: types.StringType},
+				Computed:    true,
+				Description: "The result rows, each keyed by column name. Values are rendered as strings and NULL values are null.",
+			},
+			"columns": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The columns of the result in query order.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the column.",
+						},
+						"type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ClickHouse type of the column.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read performs the read operation for the data source.
+func (d *clickhouseQueryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withOperation(ctx, "clickhouse_query", "read")
+
+	var state clickhouseQueryDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	parameters := stringsFromMap(ctx, state.Parameters, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	queryCtx := clickhouse.Context(
+		withQuerySettings(ctx, requiredSettings, clickhouse.Settings{"readonly": 1}),
+		clickhouse.WithParameters(clickhouse.Parameters(parameters)),
+	)
+
+	rows, err := d.client.Query(queryCtx, state.Query.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to run query",
+			"An error occurred while running the query: "+err.Error(),
+		)
+		return
+	}
+	defer rows.Close()
+
+	state.Columns = []clickhouseColumnResult{}
+	for _, columnType := range rows.ColumnTypes() {
+		state.Columns = append(state.Columns, clickhouseColumnResult{
+			Name: types.StringValue(columnType.Name()),
+			Type: types.StringValue(columnType.DatabaseTypeName()),
+		})
+	}
+
+	state.Rows = []types.Map{}
+	for rows.Next() {
+		row, err := scanRow(rows)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read query result",
+				"An error occurred while reading the query result: "+err.Error(),
+			)
+			return
+		}
+		state.Rows = append(state.Rows, rowValue(row))
+	}
+
+	if err := rows.Err(); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read query result",
+			"An error occurred while reading the query result: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure configures the data source with the provider data.
+func (d *clickhouseQueryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(clickhouseClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected clickhouseClient, got something else",
+		)
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestQueryDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+data "clickhouse_query" "test" {
+  query      = "SELECT number, toString(number * {factor:UInt8}) AS scaled FROM system.numbers LIMIT 3"
+  parameters = { factor = "2" }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.clickhouse_query.test", "rows.#", "3"),
+					resource.TestCheckResourceAttr("data.clickhouse_query.test", "rows.2.scaled", "4"),
+					resource.TestCheckResourceAttr("data.clickhouse_query.test", "columns.0.name", "number"),
+					resource.TestCheckResourceAttr("data.clickhouse_query.test", "columns.0.type", "UInt64"),
+				),
+			},
+			// Read-only enforcement testing
+			{
+				Config: providerConfig + `
+data "clickhouse_query" "test" {
+  query = "CREATE TABLE default.tf_test_query (id UInt64) ENGINE = Memory"
+}
+`,
+				ExpectError: regexp.MustCompile(`readonly`),
+			},
+		},
+	})
+}
@@ -0,0 +1,196 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &clickhouseReplicasDataSource{}
+	_ datasource.DataSourceWithConfigure = &clickhouseReplicasDataSource{}
+)
+
+// clickhouseReplicasDataSource is the data source implementation.
+type clickhouseReplicasDataSource struct {
+	client clickhouseClient
+}
+
+// clickhouseReplicasDataSourceModel maps the data source schema data.
+type clickhouseReplicasDataSourceModel struct {
+	Database types.String             `tfsdk:"database"`
+	Replicas []clickhouseReplicaModel `tfsdk:"replicas"`
+}
+
+// clickhouseReplicaModel maps a replicated table as described by
+// system.replicas.
+type clickhouseReplicaModel struct {
+	Database       types.String `tfsdk:"database"`
+	Table          types.String `tfsdk:"table"`
+	ReplicaName    types.String `tfsdk:"replica_name"`
+	ZookeeperPath  types.String `tfsdk:"zookeeper_path"`
+	IsLeader       types.Bool   `tfsdk:"is_leader"`
+	IsReadonly     types.Bool   `tfsdk:"is_readonly"`
+	QueueSize      types.Int64  `tfsdk:"queue_size"`
+	AbsoluteDelay  types.Int64  `tfsdk:"absolute_delay"`
+	TotalReplicas  types.Int64  `tfsdk:"total_replicas"`
+	ActiveReplicas types.Int64  `tfsdk:"active_replicas"`
+}
+
+// Metadata returns the data source type name.
+func (d *clickhouseReplicasDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "clickhouse_replicas"
+}
+
+// Schema defines the schema for the data source.
+func (d *clickhouseReplicasDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the replicated tables on the server and their replication status.",
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return tables in this database.",
+			},
+			"replicas": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "List of replicated tables ordered by database and table.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"database": schema.StringAttribute{
+							Computed:    true,
+							Description: "The database of the table.",
+						},
+						"table": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the table.",
+						},
+						"replica_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of this replica in Keeper.",
+						},
+						"zookeeper_path": schema.StringAttribute{
+							Computed:    true,
+							Description: "The Keeper path of the table.",
+						},
+						"is_leader": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the replica can assign merges.",
+						},
+						"is_readonly": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the replica is read-only, e.g. because Keeper is unreachable.",
+						},
+						"queue_size": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The number of operations waiting in the replication queue.",
+						},
+						"absolute_delay": schema.Int64Attribute{
+							Computed:    true,
+							Description: "How far the replica lags behind, in seconds.",
+						},
+						"total_replicas": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The number of known replicas of the table.",
+						},
+						"active_replicas": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The number of replicas with a Keeper session.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read performs the read operation for the data source.
+func (d *clickhouseReplicasDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withOperation(ctx, "clickhouse_replicas", "read")
+
+	var state clickhouseReplicasDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := "SELECT database, table, replica_name, zookeeper_path, is_leader, is_readonly, toInt64(queue_size), " +
+		"toInt64(absolute_delay), toInt64(total_replicas), toInt64(active_replicas) FROM system.replicas"
+	var args []any
+	if !state.Database.IsNull() {
+		query += " WHERE database = ?"
+		args = append(args, state.Database.ValueString())
+	}
+	query += " ORDER BY database, table"
+
+	rows, err := d.client.Query(ctx, query, args...)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list replicas",
+			"An error occurred while listing the replicas: "+err.Error(),
+		)
+		return
+	}
+	defer rows.Close()
+
+	state.Replicas = []clickhouseReplicaModel{}
+	for rows.Next() {
+		var (
+			database, table, replicaName, zookeeperPath             string
+			isLeader, isReadonly                                    bool
+			queueSize, absoluteDelay, totalReplicas, activeReplicas int64
+		)
+		if err := rows.Scan(&database, &table, &replicaName, &zookeeperPath, &isLeader, &isReadonly,
+			&queueSize, &absoluteDelay, &totalReplicas, &activeReplicas); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read replica",
+				"An error occurred while reading the replica: "+err.Error(),
+			)
+			return
+		}
+		state.Replicas = append(state.Replicas, clickhouseReplicaModel{
+			Database:       types.StringValue(database),
+			Table:          types.StringValue(table),
+			ReplicaName:    types.StringValue(replicaName),
+			ZookeeperPath:  types.StringValue(zookeeperPath),
+			IsLeader:       types.BoolValue(isLeader),
+			IsReadonly:     types.BoolValue(isReadonly),
+			QueueSize:      types.Int64Value(queueSize),
+			AbsoluteDelay:  types.Int64Value(absoluteDelay),
+			TotalReplicas:  types.Int64Value(totalReplicas),
+			ActiveReplicas: types.Int64Value(activeReplicas),
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list replicas",
+			"An error occurred while listing the replicas: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure configures the data source with the provider data.
+func (d *clickhouseReplicasDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(clickhouseClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected clickhouseClient, got something else",
+		)
+		return
+	}
+
+	d.client = client
+}
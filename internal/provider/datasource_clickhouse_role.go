@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &clickhouseRoleDataSource{}
+	_ datasource.DataSourceWithConfigure = &clickhouseRoleDataSource{}
+)
+
+// clickhouseRoleDataSource is the data source implementation.
+type clickhouseRoleDataSource struct {
+	client clickhouseClient
+}
+
+// clickhouseRoleDataSourceModel maps the data source schema data.
+type clickhouseRoleDataSourceModel struct {
+	Name        types.String               `tfsdk:"name"`
+	ID          types.String               `tfsdk:"id"`
+	Storage     types.String               `tfsdk:"storage"`
+	Grants      []clickhouseGrantModel     `tfsdk:"grants"`
+	Roles       []clickhouseRoleGrantModel `tfsdk:"roles"`
+	MemberUsers []types.String             `tfsdk:"member_users"`
+	MemberRoles []types.String             `tfsdk:"member_roles"`
+}
+
+// Metadata returns the data source type name.
+func (d *clickhouseRoleDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "clickhouse_role"
+}
+
+// Schema defines the schema for the data source.
+func (d *clickhouseRoleDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up a single ClickHouse role by name.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the role to look up.",
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The UUID of the role.",
+			},
+			"storage": schema.StringAttribute{
+				Computed:    true,
+				Description: "Where the role is defined, e.g. local_directory or replicated.",
+			},
+			"grants": grantsAttribute(),
+			"roles":  roleGrantsAttribute(),
+			"member_users": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The users the role is granted to.",
+			},
+			"member_roles": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The roles the role is granted to.",
+			},
+		},
+	}
+}
+
+// Read performs the read operation for the data source.
+func (d *clickhouseRoleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withOperation(ctx, "clickhouse_role", "read")
+
+	var state clickhouseRoleDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := state.Name.ValueString()
+	found, err := d.readRole(ctx, &state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read Role",
+			"An error occurred while reading the Role: "+err.Error(),
+		)
+		return
+	}
+
+	if !found {
+		resp.Diagnostics.AddError(
+			"Role not found",
+			"The ClickHouse role "+name+" does not exist.",
+		)
+		return
+	}
+
+	if state.Grants, err = readGrants(ctx, d.client, granteeRole, name); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read Role grants",
+			"An error occurred while reading the grants of the Role: "+err.Error(),
+		)
+		return
+	}
+
+	if state.Roles, err = readRoleGrants(ctx, d.client, granteeRole, name); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read Role roles",
+			"An error occurred while reading the roles granted to the Role: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// readRole fills the attributes of the role from system.roles and its
+// members from system.role_grants. It reports false when the role does not
+// exist.
+func (d *clickhouseRoleDataSource) readRole(ctx context.Context, state *clickhouseRoleDataSourceModel) (bool, error) {
+	rows, err := d.client.Query(ctx, "SELECT toString(id), storage FROM system.roles WHERE name = ?", state.Name.ValueString())
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return false, rows.Err()
+	}
+	var id, storage string
+	if err := rows.Scan(&id, &storage); err != nil {
+		return false, err
+	}
+	state.ID = types.StringValue(id)
+	state.Storage = types.StringValue(storage)
+
+	memberRows, err := d.client.Query(ctx, "SELECT user_name, role_name FROM system.role_grants WHERE granted_role_name = ? "+
+		"ORDER BY user_name, role_name", state.Name.ValueString())
+	if err != nil {
+		return false, err
+	}
+	defer memberRows.Close()
+
+	state.MemberUsers = []types.String{}
+	state.MemberRoles = []types.String{}
+	for memberRows.Next() {
+		var userName, roleName *string
+		if err := memberRows.Scan(&userName, &roleName); err != nil {
+			return false, err
+		}
+		if userName != nil {
+			state.MemberUsers = append(state.MemberUsers, types.StringValue(*userName))
+		}
+		if roleName != nil {
+			state.MemberRoles = append(state.MemberRoles, types.StringValue(*roleName))
+		}
+	}
+	return true, memberRows.Err()
+}
+
+// Configure configures the data source with the provider data.
+func (d *clickhouseRoleDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(clickhouseClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected clickhouseClient, got something else",
+		)
+		return
+	}
+
+	d.client = client
+}
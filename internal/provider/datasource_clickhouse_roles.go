@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 
-	"github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -17,7 +16,7 @@ var (
 
 // clickhouseUsersDataSource is the data source implementation.
 type clickhouseRolesDataSource struct {
-	client clickhouse.Conn
+	client clickhouseClient
 }
 
 // clickhouseUsersDataSourceModel maps the data source schema data.
@@ -45,6 +44,8 @@ func (d *clickhouseRolesDataSource) Schema(ctx context.Context, req datasource.S
 
 // Read performs the read operation for the data source.
 func (d *clickhouseRolesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withOperation(ctx, "clickhouse_roles", "read")
+
 	var state clickhouseRolesDataSourceModel
 
 	rows, err := d.client.Query(ctx, "SHOW ROLES")
@@ -82,11 +83,11 @@ func (d *clickhouseRolesDataSource) Configure(ctx context.Context, req datasourc
 		return
 	}
 
-	client, ok := req.ProviderData.(clickhouse.Conn)
+	client, ok := req.ProviderData.(clickhouseClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			"Expected clickhouse.Conn, got something else",
+			"Expected clickhouseClient, got something else",
 		)
 		return
 	}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &clickhouseServerInfoDataSource{}
+	_ datasource.DataSourceWithConfigure = &clickhouseServerInfoDataSource{}
+)
+
+// clickhouseServerInfoDataSource is the data source implementation.
+type clickhouseServerInfoDataSource struct {
+	client clickhouseClient
+}
+
+// clickhouseServerInfoDataSourceModel maps the data source schema data.
+type clickhouseServerInfoDataSourceModel struct {
+	Version      types.String            `tfsdk:"version"`
+	VersionMajor types.Int64             `tfsdk:"version_major"`
+	VersionMinor types.Int64             `tfsdk:"version_minor"`
+	VersionPatch types.Int64             `tfsdk:"version_patch"`
+	BuildOptions map[string]types.String `tfsdk:"build_options"`
+	Capabilities map[string]types.Bool   `tfsdk:"capabilities"`
+}
+
+// Metadata returns the data source type name.
+func (d *clickhouseServerInfoDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "clickhouse_server_info"
+}
+
+// Schema defines the schema for the data source.
+func (d *clickhouseServerInfoDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the version of the ClickHouse server and the features the provider can use on it.",
+		Attributes: map[string]schema.Attribute{
+			"version": schema.StringAttribute{
+				Computed:    true,
+				Description: "The full server version, e.g. 24.8.4.13.",
+			},
+			"version_major": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The major version, i.e. the release year.",
+			},
+			"version_minor": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The minor version, i.e. the release month.",
+			},
+			"version_patch": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The patch version.",
+			},
+			"build_options": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The options the server was built with, from system.build_options.",
+			},
+			"capabilities": schema.MapAttribute{
+				ElementType: types.BoolType,
+				Computed:    true,
+				Description: "Whether the server supports each version-dependent feature the provider checks at plan time.",
+			},
+		},
+	}
+}
+
+// Read performs the read operation for the data source.
+func (d *clickhouseServerInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withOperation(ctx, "clickhouse_server_info", "read")
+
+	var state clickhouseServerInfoDataSourceModel
+
+	server, err := detectServerInfo(ctx, d.client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read server version",
+			"An error occurred while reading the server version: "+err.Error(),
+		)
+		return
+	}
+
+	state.Version = types.StringValue(server.version)
+	state.VersionMajor = types.Int64Value(int64(server.major))
+	state.VersionMinor = types.Int64Value(int64(server.minor))
+	state.VersionPatch = types.Int64Value(int64(server.patch))
+
+	state.BuildOptions = map[string]types.String{}
+	for name, value := range server.buildOptions {
+		state.BuildOptions[name] = types.StringValue(value)
+	}
+
+	state.Capabilities = map[string]types.Bool{}
+	for _, c := range allCapabilities {
+		state.Capabilities[c.name] = types.BoolValue(server.supports(c))
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure configures the data source with the provider data.
+func (d *clickhouseServerInfoDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(clickhouseClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected clickhouseClient, got something else",
+		)
+		return
+	}
+
+	d.client = client
+}
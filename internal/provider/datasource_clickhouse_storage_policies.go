@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &clickhouseStoragePoliciesDataSource{}
+	_ datasource.DataSourceWithConfigure = &clickhouseStoragePoliciesDataSource{}
+)
+
+// clickhouseStoragePoliciesDataSource is the data source implementation.
+type clickhouseStoragePoliciesDataSource struct {
+	client clickhouseClient
+}
+
+// clickhouseStoragePoliciesDataSourceModel maps the data source schema data.
+type clickhouseStoragePoliciesDataSourceModel struct {
+	Policies []clickhouseStoragePolicyModel `tfsdk:"policies"`
+}
+
+// clickhouseStoragePolicyModel maps a storage policy and its volumes.
+type clickhouseStoragePolicyModel struct {
+	Name    types.String                   `tfsdk:"name"`
+	Volumes []clickhouseStorageVolumeModel `tfsdk:"volumes"`
+}
+
+// clickhouseStorageVolumeModel maps a volume as described by
+// system.storage_policies.
+type clickhouseStorageVolumeModel struct {
+	Name            types.String   `tfsdk:"name"`
+	Priority        types.Int64    `tfsdk:"priority"`
+	Disks           []types.String `tfsdk:"disks"`
+	MaxDataPartSize types.Int64    `tfsdk:"max_data_part_size"`
+	MoveFactor      types.Float64  `tfsdk:"move_factor"`
+}
+
+// Metadata returns the data source type name.
+func (d *clickhouseStoragePoliciesDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "clickhouse_storage_policies"
+}
+
+// Schema defines the schema for the data source.
+func (d *clickhouseStoragePoliciesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the storage policies configured on the server.",
+		Attributes: map[string]schema.Attribute{
+			"policies": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "List of storage policies ordered by name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the storage policy.",
+						},
+						"volumes": schema.ListNestedAttribute{
+							Computed:    true,
+							Description: "The volumes of the policy in priority order.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										Computed:    true,
+										Description: "The name of the volume.",
+									},
+									"priority": schema.Int64Attribute{
+										Computed:    true,
+										Description: "The priority of the volume within the policy.",
+									},
+									"disks": schema.ListAttribute{
+										ElementType: types.StringType,
+										Computed:    true,
+										Description: "The disks of the volume.",
+									},
+									"max_data_part_size": schema.Int64Attribute{
+										Computed:    true,
+										Description: "The largest part stored on the volume in bytes. Zero means no limit.",
+									},
+									"move_factor": schema.Float64Attribute{
+										Computed:    true,
+										Description: "The free space ratio below which parts are moved to the next volume.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read performs the read operation for the data source.
+func (d *clickhouseStoragePoliciesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withOperation(ctx, "clickhouse_storage_policies", "read")
+
+	var state clickhouseStoragePoliciesDataSourceModel
+
+	rows, err := d.client.Query(ctx, "SELECT policy_name, volume_name, toInt64(volume_priority), disks, "+
+		"toInt64(max_data_part_size), toFloat64(move_factor) FROM system.storage_policies ORDER BY policy_name, volume_priority")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list storage policies",
+			"An error occurred while listing the storage policies: "+err.Error(),
+		)
+		return
+	}
+	defer rows.Close()
+
+	state.Policies = []clickhouseStoragePolicyModel{}
+	for rows.Next() {
+		var (
+			policyName, volumeName    string
+			priority, maxDataPartSize int64
+			disks                     []string
+			moveFactor                float64
+		)
+		if err := rows.Scan(&policyName, &volumeName, &priority, &disks, &maxDataPartSize, &moveFactor); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read storage policy",
+				"An error occurred while reading the storage policy: "+err.Error(),
+			)
+			return
+		}
+
+		if len(state.Policies) == 0 || state.Policies[len(state.Policies)-1].Name.ValueString() != policyName {
+			state.Policies = append(state.Policies, clickhouseStoragePolicyModel{
+				Name:    types.StringValue(policyName),
+				Volumes: []clickhouseStorageVolumeModel{},
+			})
+		}
+		policy := &state.Policies[len(state.Policies)-1]
+		policy.Volumes = append(policy.Volumes, clickhouseStorageVolumeModel{
+			Name:            types.StringValue(volumeName),
+			Priority:        types.Int64Value(priority),
+			Disks:           stringValues(disks),
+			MaxDataPartSize: types.Int64Value(maxDataPartSize),
+			MoveFactor:      types.Float64Value(moveFactor),
+		})
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure configures the data source with the provider data.
+func (d *clickhouseStoragePoliciesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(clickhouseClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected clickhouseClient, got something else",
+		)
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &clickhouseTablesDataSource{}
+	_ datasource.DataSourceWithConfigure = &clickhouseTablesDataSource{}
+)
+
+// clickhouseTablesDataSource is the data source implementation.
+type clickhouseTablesDataSource struct {
+	client clickhouseClient
+}
+
+// clickhouseTablesDataSourceModel maps the data source schema data.
+type clickhouseTablesDataSourceModel struct {
+	Database  types.String               `tfsdk:"database"`
+	NameRegex types.String               `tfsdk:"name_regex"`
+	Tables    []clickhouseTableInfoModel `tfsdk:"tables"`
+}
+
+// clickhouseTableInfoModel maps a table as described by system.tables.
+type clickhouseTableInfoModel struct {
+	Name         types.String `tfsdk:"name"`
+	UUID         types.String `tfsdk:"uuid"`
+	Engine       types.String `tfsdk:"engine"`
+	EngineFull   types.String `tfsdk:"engine_full"`
+	SortingKey   types.String `tfsdk:"sorting_key"`
+	PartitionKey types.String `tfsdk:"partition_key"`
+	PrimaryKey   types.String `tfsdk:"primary_key"`
+	TotalRows    types.Int64  `tfsdk:"total_rows"`
+	TotalBytes   types.Int64  `tfsdk:"total_bytes"`
+	Comment      types.String `tfsdk:"comment"`
+}
+
+// Metadata returns the data source type name.
+func (d *clickhouseTablesDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "clickhouse_tables"
+}
+
+// Schema defines the schema for the data source.
+func (d *clickhouseTablesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the tables of a ClickHouse database.",
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Required:    true,
+				Description: "The database to list tables from.",
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return tables whose name matches this re2 regular expression.",
+			},
+			"tables": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "List of tables in the database.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the table.",
+						},
+						"uuid": schema.StringAttribute{
+							Computed:    true,
+							Description: "The UUID of the table.",
+						},
+						"engine": schema.StringAttribute{
+							Computed:    true,
+							Description: "The table engine, e.g. MergeTree.",
+						},
+						"engine_full": schema.StringAttribute{
+							Computed:    true,
+							Description: "The table engine with its arguments and settings.",
+						},
+						"sorting_key": schema.StringAttribute{
+							Computed:    true,
+							Description: "The sorting key expression.",
+						},
+						"partition_key": schema.StringAttribute{
+							Computed:    true,
+							Description: "The partition key expression.",
+						},
+						"primary_key": schema.StringAttribute{
+							Computed:    true,
+							Description: "The primary key expression.",
+						},
+						"total_rows": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The total number of rows. Null when the engine cannot report it cheaply.",
+						},
+						"total_bytes": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The total number of bytes on storage. Null when the engine cannot report it cheaply.",
+						},
+						"comment": schema.StringAttribute{
+							Computed:    true,
+							Description: "The comment of the table.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read performs the read operation for the data source.
+func (d *clickhouseTablesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withOperation(ctx, "clickhouse_tables", "read")
+
+	var state clickhouseTablesDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := "SELECT name, toString(uuid), engine, engine_full, sorting_key, partition_key, primary_key, " +
+		"total_rows, total_bytes, comment FROM system.tables WHERE database = ?"
+	args := []any{state.Database.ValueString()}
+	if !state.NameRegex.IsNull() {
+		query += " AND match(name, ?)"
+		args = append(args, state.NameRegex.ValueString())
+	}
+	query += " ORDER BY name"
+
+	rows, err := d.client.Query(ctx, query, args...)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list tables",
+			"An error occurred while listing the tables: "+err.Error(),
+		)
+		return
+	}
+	defer rows.Close()
+
+	state.Tables = []clickhouseTableInfoModel{}
+	for rows.Next() {
+		var (
+			name, uuid, engine, engineFull, sortingKey, partitionKey, primaryKey, comment string
+			totalRows, totalBytes                                                         *uint64
+		)
+		if err := rows.Scan(&name, &uuid, &engine, &engineFull, &sortingKey, &partitionKey, &primaryKey, &totalRows, &totalBytes, &comment); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read table",
+				"An error occurred while reading the table: "+err.Error(),
+			)
+			return
+		}
+		state.Tables = append(state.Tables, clickhouseTableInfoModel{
+			Name:         types.StringValue(name),
+			UUID:         types.StringValue(uuid),
+			Engine:       types.StringValue(engine),
+			EngineFull:   types.StringValue(engineFull),
+			SortingKey:   types.StringValue(sortingKey),
+			PartitionKey: types.StringValue(partitionKey),
+			PrimaryKey:   types.StringValue(primaryKey),
+			TotalRows:    int64ValueOrNull(totalRows),
+			TotalBytes:   int64ValueOrNull(totalBytes),
+			Comment:      types.StringValue(comment),
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list tables",
+			"An error occurred while listing the tables: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure configures the data source with the provider data.
+func (d *clickhouseTablesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(clickhouseClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected clickhouseClient, got something else",
+		)
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestTablesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+data "clickhouse_tables" "test" {
+  database   = "system"
+  name_regex = "^one$"
+}
+
+data "clickhouse_columns" "test" {
+  database = "system"
+  table    = "one"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.clickhouse_tables.test", "tables.#", "1"),
+					resource.TestCheckResourceAttr("data.clickhouse_tables.test", "tables.0.engine", "SystemOne"),
+					resource.TestCheckResourceAttr("data.clickhouse_columns.test", "columns.#", "1"),
+					resource.TestCheckResourceAttr("data.clickhouse_columns.test", "columns.0.name", "dummy"),
+					resource.TestCheckResourceAttr("data.clickhouse_columns.test", "columns.0.type", "UInt8"),
+				),
+			},
+		},
+	})
+}
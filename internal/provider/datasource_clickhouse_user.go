@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &clickhouseUserDataSource{}
+	_ datasource.DataSourceWithConfigure = &clickhouseUserDataSource{}
+)
+
+// clickhouseUserDataSource is the data source implementation.
+type clickhouseUserDataSource struct {
+	client clickhouseClient
+}
+
+// clickhouseUserDataSourceModel maps the data source schema data.
+type clickhouseUserDataSourceModel struct {
+	clickhouseUserModel
+	Grants []clickhouseGrantModel     `tfsdk:"grants"`
+	Roles  []clickhouseRoleGrantModel `tfsdk:"roles"`
+}
+
+// Metadata returns the data source type name.
+func (d *clickhouseUserDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "clickhouse_user"
+}
+
+// Schema defines the schema for the data source.
+func (d *clickhouseUserDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := userAttributes()
+	attributes["name"] = schema.StringAttribute{
+		Required:    true,
+		Description: "The name of the user to look up.",
+	}
+	attributes["grants"] = grantsAttribute()
+	attributes["roles"] = roleGrantsAttribute()
+
+	resp.Schema = schema.Schema{
+		Description: "Looks up a single ClickHouse user by name.",
+		Attributes:  attributes,
+	}
+}
+
+// Read performs the read operation for the data source.
+func (d *clickhouseUserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withOperation(ctx, "clickhouse_user", "read")
+
+	var state clickhouseUserDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := state.Name.ValueString()
+	users, err := readUsers(ctx, d.client, []string{"name = ?"}, name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read User",
+			"An error occurred while reading the User: "+err.Error(),
+		)
+		return
+	}
+
+	if len(users) == 0 {
+		resp.Diagnostics.AddError(
+			"User not found",
+			"The ClickHouse user "+name+" does not exist.",
+		)
+		return
+	}
+	state.clickhouseUserModel = users[0]
+
+	if state.Grants, err = readGrants(ctx, d.client, granteeUser, name); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read User grants",
+			"An error occurred while reading the grants of the User: "+err.Error(),
+		)
+		return
+	}
+
+	if state.Roles, err = readRoleGrants(ctx, d.client, granteeUser, name); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read User roles",
+			"An error occurred while reading the roles granted to the User: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure configures the data source with the provider data.
+func (d *clickhouseUserDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(clickhouseClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected clickhouseClient, got something else",
+		)
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestUserDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `data "clickhouse_user" "test" { name = "default" }`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.clickhouse_user.test", "name", "default"),
+					resource.TestCheckResourceAttrSet("data.clickhouse_user.test", "id"),
+					resource.TestCheckResourceAttrSet("data.clickhouse_user.test", "grants.#"),
+				),
+			},
+			// Missing user testing
+			{
+				Config:      providerConfig + `data "clickhouse_user" "test" { name = "tf_test_missing" }`,
+				ExpectError: regexp.MustCompile(`User not found`),
+			},
+		},
+	})
+}
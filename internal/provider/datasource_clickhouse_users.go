@@ -2,8 +2,8 @@ package provider
 
 import (
 	"context"
+	"strings"
 
-	"github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -17,12 +17,91 @@ var (
 
 // clickhouseUsersDataSource is the data source implementation.
 type clickhouseUsersDataSource struct {
-	client clickhouse.Conn
+	client clickhouseClient
 }
 
 // clickhouseUsersDataSourceModel maps the data source schema data.
 type clickhouseUsersDataSourceModel struct {
-	Users []types.String `tfsdk:"users"`
+	NameRegex types.String          `tfsdk:"name_regex"`
+	Storage   types.String          `tfsdk:"storage"`
+	Users     []clickhouseUserModel `tfsdk:"users"`
+}
+
+// clickhouseUserModel maps a user as described by system.users.
+type clickhouseUserModel struct {
+	Name               types.String   `tfsdk:"name"`
+	ID                 types.String   `tfsdk:"id"`
+	Storage            types.String   `tfsdk:"storage"`
+	AuthType           []types.String `tfsdk:"auth_type"`
+	HostIP             []types.String `tfsdk:"host_ip"`
+	HostNames          []types.String `tfsdk:"host_names"`
+	HostNamesRegexp    []types.String `tfsdk:"host_names_regexp"`
+	HostNamesLike      []types.String `tfsdk:"host_names_like"`
+	DefaultRolesAll    types.Bool     `tfsdk:"default_roles_all"`
+	DefaultRoles       []types.String `tfsdk:"default_roles"`
+	DefaultRolesExcept []types.String `tfsdk:"default_roles_except"`
+	DefaultDatabase    types.String   `tfsdk:"default_database"`
+}
+
+// userAttributes describes the attributes of a user read from system.users.
+func userAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			Computed:    true,
+			Description: "The name of the user.",
+		},
+		"id": schema.StringAttribute{
+			Computed:    true,
+			Description: "The UUID of the user.",
+		},
+		"storage": schema.StringAttribute{
+			Computed:    true,
+			Description: "Where the user is defined, e.g. local_directory, replicated or users_xml.",
+		},
+		"auth_type": schema.ListAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+			Description: "The authentication methods of the user.",
+		},
+		"host_ip": schema.ListAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+			Description: "IP addresses and subnets the user may connect from.",
+		},
+		"host_names": schema.ListAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+			Description: "Host names the user may connect from.",
+		},
+		"host_names_regexp": schema.ListAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+			Description: "Regular expressions matching host names the user may connect from.",
+		},
+		"host_names_like": schema.ListAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+			Description: "LIKE patterns matching host names the user may connect from.",
+		},
+		"default_roles_all": schema.BoolAttribute{
+			Computed:    true,
+			Description: "Whether every granted role is a default role.",
+		},
+		"default_roles": schema.ListAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+			Description: "The default roles of the user.",
+		},
+		"default_roles_except": schema.ListAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+			Description: "Granted roles excluded from the default roles.",
+		},
+		"default_database": schema.StringAttribute{
+			Computed:    true,
+			Description: "The default database of the user.",
+		},
+	}
 }
 
 // Metadata returns the data source type name.
@@ -34,20 +113,108 @@ func (d *clickhouseUsersDataSource) Metadata(_ context.Context, _ datasource.Met
 func (d *clickhouseUsersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
-			"users": schema.ListAttribute{
-				ElementType: types.StringType,
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return users whose name matches this re2 regular expression.",
+			},
+			"storage": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return users defined in this storage, e.g. users_xml.",
+			},
+			"users": schema.ListNestedAttribute{
 				Computed:    true,
 				Description: "List of Users in the ClickHouse server.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: userAttributes(),
+				},
 			},
 		},
 	}
 }
 
+// readUsers lists the users from system.users matching the given conditions,
+// ordered by name.
+func readUsers(ctx context.Context, client clickhouseClient, conditions []string, args ...any) ([]clickhouseUserModel, error) {
+	query := "SELECT name, toString(id), storage, auth_type, host_ip, host_names, host_names_regexp, host_names_like, " +
+		"default_roles_all, default_roles_list, default_roles_except, default_database FROM system.users"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY name"
+
+	rows, err := client.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// auth_type is a single enum on older servers and an array on servers that
+	// support several authentication methods per user.
+	authTypeIsArray := strings.HasPrefix(rows.ColumnTypes()[3].DatabaseTypeName(), "Array")
+
+	users := []clickhouseUserModel{}
+	for rows.Next() {
+		var (
+			name, id, storage, defaultDatabase                string
+			authType                                          string
+			authTypes                                         []string
+			hostIP, hostNames, hostNamesRegexp, hostNamesLike []string
+			defaultRoles, defaultRolesExcept                  []string
+			defaultRolesAll                                   uint8
+		)
+		var authTypeDest any = &authType
+		if authTypeIsArray {
+			authTypeDest = &authTypes
+		}
+		if err := rows.Scan(&name, &id, &storage, authTypeDest, &hostIP, &hostNames, &hostNamesRegexp, &hostNamesLike,
+			&defaultRolesAll, &defaultRoles, &defaultRolesExcept, &defaultDatabase); err != nil {
+			return nil, err
+		}
+		if !authTypeIsArray {
+			authTypes = []string{authType}
+		}
+
+		users = append(users, clickhouseUserModel{
+			Name:               types.StringValue(name),
+			ID:                 types.StringValue(id),
+			Storage:            types.StringValue(storage),
+			AuthType:           stringValues(authTypes),
+			HostIP:             stringValues(hostIP),
+			HostNames:          stringValues(hostNames),
+			HostNamesRegexp:    stringValues(hostNamesRegexp),
+			HostNamesLike:      stringValues(hostNamesLike),
+			DefaultRolesAll:    types.BoolValue(defaultRolesAll == 1),
+			DefaultRoles:       stringValues(defaultRoles),
+			DefaultRolesExcept: stringValues(defaultRolesExcept),
+			DefaultDatabase:    types.StringValue(defaultDatabase),
+		})
+	}
+	return users, rows.Err()
+}
+
 // Read performs the read operation for the data source.
 func (d *clickhouseUsersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withOperation(ctx, "clickhouse_users", "read")
+
 	var state clickhouseUsersDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var conditions []string
+	var args []any
+	if !state.NameRegex.IsNull() {
+		conditions = append(conditions, "match(name, ?)")
+		args = append(args, state.NameRegex.ValueString())
+	}
+	if !state.Storage.IsNull() {
+		conditions = append(conditions, "storage = ?")
+		args = append(args, state.Storage.ValueString())
+	}
 
-	rows, err := d.client.Query(ctx, "SHOW USERS")
+	users, err := readUsers(ctx, d.client, conditions, args...)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to list Users",
@@ -55,24 +222,10 @@ func (d *clickhouseUsersDataSource) Read(ctx context.Context, req datasource.Rea
 		)
 		return
 	}
-	defer rows.Close()
-
-	var Users []types.String
-	for rows.Next() {
-		var database string
-		if err := rows.Scan(&database); err != nil {
-			resp.Diagnostics.AddError(
-				"Unable to read database name",
-				"An error occurred while reading the database name: "+err.Error(),
-			)
-			return
-		}
-		Users = append(Users, types.StringValue(database))
-	}
 
-	state.Users = Users
+	state.Users = users
 
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 }
 
@@ -82,11 +235,11 @@ func (d *clickhouseUsersDataSource) Configure(ctx context.Context, req datasourc
 		return
 	}
 
-	client, ok := req.ProviderData.(clickhouse.Conn)
+	client, ok := req.ProviderData.(clickhouseClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			"Expected clickhouse.Conn, got something else",
+			"Expected clickhouseClient, got something else",
 		)
 		return
 	}
@@ -20,6 +20,20 @@ func TestUsersDataSource(t *testing.T) {
 
 				),
 			},
+			// Filter testing
+			{
+				Config: providerConfig + `
+data "clickhouse_users" "test" {
+  name_regex = "^default$"
+  storage    = "users_xml"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.clickhouse_users.test", "users.#", "1"),
+					resource.TestCheckResourceAttr("data.clickhouse_users.test", "users.0.name", "default"),
+					resource.TestCheckResourceAttr("data.clickhouse_users.test", "users.0.storage", "users_xml"),
+				),
+			},
 		},
 	})
 }
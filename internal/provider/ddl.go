@@ -0,0 +1,234 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ddlPollInterval is how often the distributed DDL queue is checked while
+// waiting for a statement to finish on every host.
+var ddlPollInterval = 2 * time.Second
+
+// ddlHostFailure is a host on which a distributed DDL statement failed.
+type ddlHostFailure struct {
+	host string
+	err  string
+}
+
+// distributedDDLError reports the hosts on which an ON CLUSTER statement
+// failed or did not finish.
+type distributedDDLError struct {
+	hosts    int
+	failures []ddlHostFailure
+	pending  int
+}
+
+func (e *distributedDDLError) Error() string {
+	var lines []string
+	for _, failure := range e.failures {
+		lines = append(lines, failure.host+": "+failure.err)
+	}
+	if e.pending > 0 {
+		lines = append(lines, fmt.Sprintf("%d of %d hosts did not finish executing the statement", e.pending, e.hosts))
+	}
+	return "distributed DDL did not succeed on every host:\n" + strings.Join(lines, "\n")
+}
+
+// ddlStatus is the outcome of a distributed DDL statement.
+type ddlStatus struct {
+	hosts    int
+	finished int
+	failures []ddlHostFailure
+}
+
+// err returns the error for the hosts that failed or have not finished.
+func (s ddlStatus) err() error {
+	pending := max(s.hosts-s.finished-len(s.failures), 0)
+	if len(s.failures) == 0 && pending == 0 {
+		return nil
+	}
+	return &distributedDDLError{hosts: s.hosts, failures: s.failures, pending: pending}
+}
+
+// ExecDDL runs a DDL statement and checks the status row returned for every
+// host when it runs ON CLUSTER. The server is asked not to throw on host
+// failures so that each one can be reported.
+func (c *providerClient) ExecDDL(ctx context.Context, query string, args ...any) error {
+	tag, err := newDDLTag()
+	if err != nil {
+		return err
+	}
+	ctx = withQuerySettings(ctx, defaultSettings, clickhouse.Settings{"distributed_ddl_output_mode": "never_throw"})
+	ctx = withQuerySettings(ctx, requiredSettings, clickhouse.Settings{"log_comment": tag})
+
+	rows, err := c.query(ctx, c.sendDDL, query, args...)
+	if err != nil {
+		return err
+	}
+	status, err := readDDLStatus(rows)
+	if err != nil {
+		return checkTimeout(ctx, err)
+	}
+
+	if !c.waitDDL || len(status.failures) > 0 || (status.hosts > 0 && status.finished >= status.hosts) {
+		return status.err()
+	}
+	return checkTimeout(ctx, c.waitForDDLQueue(ctx, tag, status.hosts))
+}
+
+// newDDLTag returns a random log_comment used to find a statement in the
+// distributed DDL queue.
+func newDDLTag() (string, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return "terraform-provider-clickhouse " + hex.EncodeToString(id), nil
+}
+
+// readDDLStatus reads the result of a DDL statement. ON CLUSTER statements
+// return a row per host with its status and error; other statements return
+// no rows.
+func readDDLStatus(rows driver.Rows) (ddlStatus, error) {
+	defer rows.Close()
+
+	var status ddlStatus
+	remaining := 0
+	for rows.Next() {
+		row, err := scanRow(rows)
+		if err != nil {
+			return status, err
+		}
+		host := row["host"]
+		if host == nil {
+			continue
+		}
+
+		status.hosts++
+		if code := row["status"]; code == nil {
+			continue
+		} else if *code == "0" {
+			status.finished++
+		} else {
+			status.failures = append(status.failures, ddlHostFailure{host: hostPort(host, row["port"]), err: valueOrEmpty(row["error"])})
+		}
+		if value := row["num_hosts_remaining"]; value != nil {
+			remaining, _ = strconv.Atoi(*value)
+		}
+	}
+
+	// Hosts that did not answer within distributed_ddl_task_timeout have no
+	// row; the last row counts them.
+	status.hosts += remaining
+	return status, rows.Err()
+}
+
+// waitForDDLQueue polls system.distributed_ddl_queue until the statement
+// tagged with tag finished on the given number of hosts, and on every host
+// the queue lists. Statements that did not run ON CLUSTER have no entry.
+func (c *providerClient) waitForDDLQueue(ctx context.Context, tag string, hosts int) error {
+	for first := true; ; first = false {
+		rows, err := c.Query(ctx, "SELECT host, port, status, exception_code, exception_text FROM system.distributed_ddl_queue WHERE settings['log_comment'] = ?", tag)
+		if err != nil {
+			return err
+		}
+		status, err := readDDLQueue(rows)
+		if err != nil {
+			return err
+		}
+		if first && status.hosts == 0 && hosts == 0 {
+			return nil
+		}
+		status.hosts = max(status.hosts, hosts)
+		if len(status.failures) > 0 || status.finished >= status.hosts {
+			return status.err()
+		}
+
+		tflog.Debug(ctx, "Waiting for distributed DDL to finish on every host", map[string]any{
+			"finished": status.finished,
+			"hosts":    status.hosts,
+		})
+
+		timer := time.NewTimer(ddlPollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(ctx.Err(), status.err())
+		case <-timer.C:
+		}
+	}
+}
+
+// readDDLQueue reads the entries of a statement from the distributed DDL
+// queue.
+func readDDLQueue(rows driver.Rows) (ddlStatus, error) {
+	defer rows.Close()
+
+	var status ddlStatus
+	for rows.Next() {
+		row, err := scanRow(rows)
+		if err != nil {
+			return status, err
+		}
+
+		status.hosts++
+		if code := row["exception_code"]; code != nil && *code != "0" {
+			status.failures = append(status.failures, ddlHostFailure{
+				host: hostPort(row["host"], row["port"]),
+				err:  "code: " + *code + ", message: " + valueOrEmpty(row["exception_text"]),
+			})
+		} else if state := row["status"]; state != nil && (*state == "Finished" || *state == "Removing") {
+			status.finished++
+		}
+	}
+	return status, rows.Err()
+}
+
+// hostPort joins a host and port read from a system table.
+func hostPort(host, port *string) string {
+	if port == nil {
+		return valueOrEmpty(host)
+	}
+	return valueOrEmpty(host) + ":" + *port
+}
+
+// valueOrEmpty dereferences a value read with scanRow.
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// addDDLError reports a failed DDL statement. Each host an ON CLUSTER
+// statement failed on gets a diagnostic of its own.
+func addDDLError(diags *diag.Diagnostics, summary, detail string, err error) {
+	var ddlErr *distributedDDLError
+	if !errors.As(err, &ddlErr) {
+		diags.AddError(summary, detail+", unexpected error: "+err.Error())
+		return
+	}
+
+	for _, failure := range ddlErr.failures {
+		diags.AddError(summary, detail+" on host "+failure.host+": "+failure.err)
+	}
+	if ddlErr.pending > 0 {
+		message := fmt.Sprintf("%s: %d of %d hosts did not finish executing the statement.", detail, ddlErr.pending, ddlErr.hosts)
+		var timeoutErr *timeoutError
+		if errors.As(err, &timeoutErr) {
+			message += fmt.Sprintf(" The %s timeout can be raised in the timeouts block of the resource.", timeoutErr.timeout)
+		}
+		diags.AddError(summary, message)
+	}
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestReadDDLStatus(t *testing.T) {
+	int64Type := reflect.TypeOf(int64(0))
+	uint16Type := reflect.TypeOf(uint16(0))
+	uint64Type := reflect.TypeOf(uint64(0))
+	columns := []fakeColumn{
+		{"host", "String", stringType},
+		{"port", "UInt16", uint16Type},
+		{"status", "Int64", int64Type},
+		{"error", "String", stringType},
+		{"num_hosts_remaining", "UInt64", uint64Type},
+		{"num_hosts_active", "UInt64", uint64Type},
+	}
+
+	status, err := readDDLStatus(&fakeRows{columns: columns, index: -1, values: [][]any{
+		{"ch-1", uint16(9000), int64(0), "", uint64(3), uint64(3)},
+		{"ch-2", uint16(9000), int64(57), "Code: 57. DB::Exception: Table default.t already exists.", uint64(2), uint64(2)},
+		{"ch-3", uint16(9000), int64(0), "", uint64(1), uint64(1)},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.hosts != 4 || status.finished != 2 || len(status.failures) != 1 {
+		t.Fatalf("readDDLStatus() = %+v, want 4 hosts, 2 finished and 1 failure", status)
+	}
+	if failure := status.failures[0]; failure.host != "ch-2:9000" || !strings.Contains(failure.err, "already exists") {
+		t.Errorf("failure = %+v", failure)
+	}
+
+	var ddlErr *distributedDDLError
+	if err := status.err(); !errors.As(err, &ddlErr) || ddlErr.pending != 1 {
+		t.Errorf("status.err() = %v, want one failure and one pending host", err)
+	}
+
+	status, err = readDDLStatus(&fakeRows{index: -1})
+	if err != nil || status.err() != nil {
+		t.Errorf("readDDLStatus() of a statement without ON CLUSTER = %+v, %v", status, err)
+	}
+}
+
+func TestReadDDLQueue(t *testing.T) {
+	columns := []fakeColumn{
+		{"host", "String", stringType},
+		{"port", "UInt16", reflect.TypeOf(uint16(0))},
+		{"status", "Enum8", stringType},
+		{"exception_code", "UInt16", reflect.TypeOf(uint16(0))},
+		{"exception_text", "String", stringType},
+	}
+
+	status, err := readDDLQueue(&fakeRows{columns: columns, index: -1, values: [][]any{
+		{"ch-1", uint16(9000), "Finished", uint16(0), ""},
+		{"ch-2", uint16(9000), "Active", uint16(0), ""},
+		{"ch-3", uint16(9000), "Inactive", uint16(0), ""},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.hosts != 3 || status.finished != 1 || len(status.failures) != 0 {
+		t.Errorf("readDDLQueue() = %+v, want 3 hosts and 1 finished", status)
+	}
+
+	status, err = readDDLQueue(&fakeRows{columns: columns, index: -1, values: [][]any{
+		{"ch-1", uint16(9000), "Finished", uint16(0), ""},
+		{"ch-2", uint16(9000), "Finished", uint16(253), "Replica already exists"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(status.failures) != 1 || status.failures[0].err != "code: 253, message: Replica already exists" {
+		t.Errorf("readDDLQueue() failures = %+v", status.failures)
+	}
+}
+
+func TestAddDDLError(t *testing.T) {
+	var diags diag.Diagnostics
+	addDDLError(&diags, "Error creating ClickHouse table", "Could not create ClickHouse table", &distributedDDLError{
+		hosts: 3,
+		failures: []ddlHostFailure{
+			{host: "ch-1:9000", err: "Code: 57. Table already exists."},
+			{host: "ch-2:9000", err: "Code: 999. Keeper session expired."},
+		},
+		pending: 1,
+	})
+	if len(diags) != 3 {
+		t.Fatalf("got %d diagnostics, want one per failed host and one for the pending host", len(diags))
+	}
+	if detail := diags[1].Detail(); detail != "Could not create ClickHouse table on host ch-2:9000: Code: 999. Keeper session expired." {
+		t.Errorf("detail = %q", detail)
+	}
+	if detail := diags[2].Detail(); !strings.Contains(detail, "1 of 3 hosts did not finish") {
+		t.Errorf("detail = %q", detail)
+	}
+
+	diags = nil
+	addDDLError(&diags, "Error creating ClickHouse table", "Could not create ClickHouse table", errors.New("syntax error"))
+	if len(diags) != 1 || diags[0].Detail() != "Could not create ClickHouse table, unexpected error: syntax error" {
+		t.Errorf("diagnostics = %v", diags)
+	}
+}
@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// clickhouseGrantModel maps a privilege as described by system.grants.
+type clickhouseGrantModel struct {
+	AccessType      types.String `tfsdk:"access_type"`
+	Database        types.String `tfsdk:"database"`
+	Table           types.String `tfsdk:"table"`
+	Column          types.String `tfsdk:"column"`
+	IsPartialRevoke types.Bool   `tfsdk:"is_partial_revoke"`
+	GrantOption     types.Bool   `tfsdk:"grant_option"`
+}
+
+// clickhouseRoleGrantModel maps a role granted to a user or role as described
+// by system.role_grants.
+type clickhouseRoleGrantModel struct {
+	Role            types.String `tfsdk:"role"`
+	IsDefault       types.Bool   `tfsdk:"is_default"`
+	WithAdminOption types.Bool   `tfsdk:"with_admin_option"`
+}
+
+// grantsAttribute describes the privileges granted directly to a user or role.
+func grantsAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Computed:    true,
+		Description: "The privileges granted directly, excluding those inherited through roles.",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"access_type": schema.StringAttribute{
+					Computed:    true,
+					Description: "The privilege, e.g. SELECT.",
+				},
+				"database": schema.StringAttribute{
+					Computed:    true,
+					Description: "The database the privilege applies to. Null for global privileges.",
+				},
+				"table": schema.StringAttribute{
+					Computed:    true,
+					Description: "The table the privilege applies to. Null for database or global privileges.",
+				},
+				"column": schema.StringAttribute{
+					Computed:    true,
+					Description: "The column the privilege applies to. Null for table or wider privileges.",
+				},
+				"is_partial_revoke": schema.BoolAttribute{
+					Computed:    true,
+					Description: "Whether this entry revokes part of a wider privilege.",
+				},
+				"grant_option": schema.BoolAttribute{
+					Computed:    true,
+					Description: "Whether the privilege was granted WITH GRANT OPTION.",
+				},
+			},
+		},
+	}
+}
+
+// roleGrantsAttribute describes the roles granted to a user or role.
+func roleGrantsAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Computed:    true,
+		Description: "The roles granted.",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"role": schema.StringAttribute{
+					Computed:    true,
+					Description: "The name of the granted role.",
+				},
+				"is_default": schema.BoolAttribute{
+					Computed:    true,
+					Description: "Whether the role is enabled by default.",
+				},
+				"with_admin_option": schema.BoolAttribute{
+					Computed:    true,
+					Description: "Whether the role was granted WITH ADMIN OPTION.",
+				},
+			},
+		},
+	}
+}
+
+// granteeColumn selects whether grants are looked up for a user or a role.
+type granteeColumn string
+
+const (
+	granteeUser granteeColumn = "user_name"
+	granteeRole granteeColumn = "role_name"
+)
+
+// readGrants lists the privileges granted directly to a user or role.
+func readGrants(ctx context.Context, client clickhouseClient, column granteeColumn, name string) ([]clickhouseGrantModel, error) {
+	rows, err := client.Query(ctx, "SELECT toString(access_type), database, table, column, is_partial_revoke, grant_option "+
+		"FROM system.grants WHERE "+string(column)+" = ? ORDER BY access_type, database, table, column", name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	grants := []clickhouseGrantModel{}
+	for rows.Next() {
+		var (
+			accessType                   string
+			database, table, columnName  *string
+			isPartialRevoke, grantOption uint8
+		)
+		if err := rows.Scan(&accessType, &database, &table, &columnName, &isPartialRevoke, &grantOption); err != nil {
+			return nil, err
+		}
+		grants = append(grants, clickhouseGrantModel{
+			AccessType:      types.StringValue(accessType),
+			Database:        stringValueOrNull(database),
+			Table:           stringValueOrNull(table),
+			Column:          stringValueOrNull(columnName),
+			IsPartialRevoke: types.BoolValue(isPartialRevoke == 1),
+			GrantOption:     types.BoolValue(grantOption == 1),
+		})
+	}
+	return grants, rows.Err()
+}
+
+// readRoleGrants lists the roles granted to a user or role.
+func readRoleGrants(ctx context.Context, client clickhouseClient, column granteeColumn, name string) ([]clickhouseRoleGrantModel, error) {
+	rows, err := client.Query(ctx, "SELECT granted_role_name, granted_role_is_default, with_admin_option "+
+		"FROM system.role_grants WHERE "+string(column)+" = ? ORDER BY granted_role_name", name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roleGrants := []clickhouseRoleGrantModel{}
+	for rows.Next() {
+		var (
+			role                       string
+			isDefault, withAdminOption uint8
+		)
+		if err := rows.Scan(&role, &isDefault, &withAdminOption); err != nil {
+			return nil, err
+		}
+		roleGrants = append(roleGrants, clickhouseRoleGrantModel{
+			Role:            types.StringValue(role),
+			IsDefault:       types.BoolValue(isDefault == 1),
+			WithAdminOption: types.BoolValue(withAdminOption == 1),
+		})
+	}
+	return roleGrants, rows.Err()
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// stringsFromSet converts a set of strings into a Go slice. Null and unknown
+// sets are treated as empty.
+func stringsFromSet(ctx context.Context, set types.Set, diags *diag.Diagnostics) []string {
+	if set.IsNull() || set.IsUnknown() {
+		return nil
+	}
+	var values []string
+	diags.Append(set.ElementsAs(ctx, &values, false)...)
+	return values
+}
+
+// stringsFromList converts a list of strings into a Go slice. Null and unknown
+// lists are treated as empty.
+func stringsFromList(ctx context.Context, list types.List, diags *diag.Diagnostics) []string {
+	if list.IsNull() || list.IsUnknown() {
+		return nil
+	}
+	var values []string
+	diags.Append(list.ElementsAs(ctx, &values, false)...)
+	return values
+}
+
+// stringSetValue builds the state value for an optional set of strings,
+// keeping it null when it was null before and the server reports nothing.
+func stringSetValue(current types.Set, values []string) types.Set {
+	if len(values) == 0 && current.IsNull() {
+		return current
+	}
+	elements := make([]attr.Value, 0, len(values))
+	for _, value := range values {
+		elements = append(elements, types.StringValue(value))
+	}
+	return types.SetValueMust(types.StringType, elements)
+}
+
+// stringValueOrNull returns a null string for nil pointers.
+func stringValueOrNull(value *string) types.String {
+	if value == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(*value)
+}
+
+// stringListValue builds the state value for an optional list of strings,
+// keeping it null when it was null before and the server reports nothing.
+func stringListValue(current types.List, values []string) types.List {
+	if len(values) == 0 && current.IsNull() {
+		return current
+	}
+	return stringList(values)
+}
+
+// stringList converts a Go slice into a list of strings.
+func stringList(values []string) types.List {
+	elements := make([]attr.Value, 0, len(values))
+	for _, value := range values {
+		elements = append(elements, types.StringValue(value))
+	}
+	return types.ListValueMust(types.StringType, elements)
+}
+
+// stringsFromMap converts a map of strings into a Go map. Null and unknown
+// maps are treated as empty.
+func stringsFromMap(ctx context.Context, m types.Map, diags *diag.Diagnostics) map[string]string {
+	if m.IsNull() || m.IsUnknown() {
+		return nil
+	}
+	values := map[string]string{}
+	diags.Append(m.ElementsAs(ctx, &values, false)...)
+	return values
+}
+
+// stringMapValue builds the state value for an optional map of strings,
+// keeping it null when it was null before and the server reports nothing.
+func stringMapValue(current types.Map, values map[string]string) types.Map {
+	if len(values) == 0 && current.IsNull() {
+		return current
+	}
+	elements := make(map[string]attr.Value, len(values))
+	for key, value := range values {
+		elements[key] = types.StringValue(value)
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
+
+// rowValue converts a scanned result row into a map of strings, keeping NULL
+// values as null elements.
+func rowValue(row map[string]*string) types.Map {
+	elements := make(map[string]attr.Value, len(row))
+	for name, value := range row {
+		elements[name] = stringValueOrNull(value)
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
+
+// stringValues converts a Go slice into a slice of string values.
+func stringValues(values []string) []types.String {
+	converted := make([]types.String, 0, len(values))
+	for _, value := range values {
+		converted = append(converted, types.StringValue(value))
+	}
+	return converted
+}
+
+// int64ValueOrNull converts an optional unsigned counter into an Int64 value.
+func int64ValueOrNull(value *uint64) types.Int64 {
+	if value == nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(*value))
+}
@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// redactedLiteral replaces secret string literals in logged statements.
+const redactedLiteral = "'***'"
+
+var (
+	// credentialFunctions are the table engines and table functions whose
+	// arguments may carry credentials. All of their string arguments are
+	// redacted.
+	credentialFunctions = map[string]bool{
+		"azureblobstorage": true, "azureblobstoragecluster": true, "azurequeue": true,
+		"deltalake": true, "gcs": true, "hdfs": true, "hudi": true, "iceberg": true,
+		"jdbc": true, "mongodb": true, "mysql": true, "odbc": true, "postgresql": true,
+		"redis": true, "remote": true, "remotesecure": true, "s3": true, "s3cluster": true,
+		"s3queue": true, "url": true,
+	}
+
+	// secretSettingPattern matches the names of settings and arguments
+	// assigned with name = 'value' whose values are secret.
+	secretSettingPattern = regexp.MustCompile(`(?i)password|passwd|secret|token|credential|private_key|access_key|connection_string`)
+
+	// namedCollectionPattern matches statements whose values are all secret.
+	namedCollectionPattern = regexp.MustCompile(`(?is)^\s*(CREATE|ALTER)\s+NAMED\s+COLLECTION\b`)
+
+	// literalEscapes undoes the escapes of a string literal.
+	literalEscapes = strings.NewReplacer(`\\`, `\`, `\'`, `'`, `''`, `'`)
+)
+
+// withOperation adds the resource or data source type and the operation to
+// the fields of every message logged with ctx.
+func withOperation(ctx context.Context, typeName, operation string) context.Context {
+	ctx = tflog.SetField(ctx, "resource_type", typeName)
+	return tflog.SetField(ctx, "operation", operation)
+}
+
+// withRedactedStatement returns the statement with its secrets redacted, and
+// a context that masks those secrets in every logged field, such as server
+// errors quoting the statement.
+func withRedactedStatement(ctx context.Context, query string) (context.Context, string) {
+	statement, secrets := redactSQL(query)
+	if len(secrets) > 0 {
+		ctx = tflog.MaskAllFieldValuesStrings(ctx, secrets...)
+	}
+	return ctx, statement
+}
+
+// logStatement logs an executed statement at DEBUG.
+func logStatement(ctx context.Context, statement string, start time.Time, err error) {
+	fields := map[string]any{
+		"statement":   statement,
+		"duration_ms": time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	tflog.Debug(ctx, "Executed ClickHouse statement", fields)
+}
+
+// redactSQL replaces the string literals of a statement that hold secrets
+// with '***' and returns the secrets it removed. Passwords, SALT and KEY
+// values of IDENTIFIED clauses, named collection values, any value assigned
+// to a secret setting, quoted or not, and every string argument of engines and table functions
+// that connect to other systems are redacted.
+func redactSQL(statement string) (string, []string) {
+	var out strings.Builder
+	var secrets []string
+	// tokens holds the last significant tokens, most recent last, with
+	// keywords and identifiers in upper case.
+	var tokens []string
+	// masked holds, for every open parenthesis, whether string literals
+	// within it are redacted.
+	var masked []bool
+	allSecret := namedCollectionPattern.MatchString(statement)
+
+	token := func(back int) string {
+		if len(tokens) < back {
+			return ""
+		}
+		return tokens[len(tokens)-back]
+	}
+	push := func(t string) {
+		tokens = append(tokens, t)
+		if len(tokens) > 4 {
+			tokens = tokens[1:]
+		}
+	}
+	// assignedSecret reports whether the value at hand, signed or not, is
+	// assigned to a secret setting.
+	assignedSecret := func() bool {
+		back := 1
+		if t := token(1); t == "-" || t == "+" {
+			back = 2
+		}
+		return token(back) == "=" && (allSecret || secretSettingPattern.MatchString(token(back+1)))
+	}
+	redact := func(value string) {
+		out.WriteString(redactedLiteral)
+		if value != "" {
+			secrets = append(secrets, value)
+		}
+		if unescaped := literalEscapes.Replace(value); unescaped != value {
+			secrets = append(secrets, unescaped)
+		}
+	}
+
+	for i := 0; i < len(statement); {
+		c := statement[i]
+		switch {
+		case c == '\'':
+			end, value := quoted(statement, i)
+			secret := allSecret || (len(masked) > 0 && masked[len(masked)-1]) || assignedSecret()
+			switch token(1) {
+			case "BY", "SALT", "KEY":
+				secret = true
+			}
+			if secret {
+				redact(value)
+			} else {
+				out.WriteString(statement[i:end])
+			}
+			push("'")
+			i = end
+		case c == '"' || c == '`':
+			end, name := quoted(statement, i)
+			out.WriteString(statement[i:end])
+			push(strings.ToUpper(name))
+			i = end
+		case c == '(':
+			function := strings.ToLower(token(1))
+			mask := (len(masked) > 0 && masked[len(masked)-1]) || credentialFunctions[function] ||
+				(token(2) == "=" && token(3) == "ENGINE")
+			masked = append(masked, mask)
+			out.WriteByte(c)
+			push("(")
+			i++
+		case c == ')':
+			if len(masked) > 0 {
+				masked = masked[:len(masked)-1]
+			}
+			out.WriteByte(c)
+			push(")")
+			i++
+		case isIdentifierChar(rune(c)):
+			end := i
+			for end < len(statement) && isIdentifierChar(rune(statement[end])) {
+				end++
+			}
+			if assignedSecret() {
+				redact(statement[i:end])
+				push("'")
+			} else {
+				out.WriteString(statement[i:end])
+				push(strings.ToUpper(statement[i:end]))
+			}
+			i = end
+		case unicode.IsSpace(rune(c)):
+			out.WriteByte(c)
+			i++
+		default:
+			out.WriteByte(c)
+			push(string(c))
+			i++
+		}
+	}
+	return out.String(), secrets
+}
+
+// quoted returns the index just past the quoted string or identifier
+// starting at start, honouring backslash escapes and doubled quotes, and the
+// text between the quotes. An unterminated quote runs to the end of the
+// statement.
+func quoted(statement string, start int) (int, string) {
+	quote := statement[start]
+	for i := start + 1; i < len(statement); i++ {
+		switch statement[i] {
+		case '\\':
+			i++
+		case quote:
+			if i+1 < len(statement) && statement[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1, statement[start+1 : i]
+		}
+	}
+	return len(statement), statement[start+1:]
+}
+
+// isIdentifierChar reports whether c can be part of an unquoted identifier
+// or keyword.
+func isIdentifierChar(c rune) bool {
+	return c == '_' || c == '.' || c < unicode.MaxASCII && (unicode.IsLetter(c) || unicode.IsDigit(c)) || c >= unicode.MaxASCII
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRedactSQL(t *testing.T) {
+	for _, test := range []struct {
+		statement string
+		want      string
+		secrets   []string
+	}{
+		{
+			"CREATE USER alice IDENTIFIED BY 's3cr3t'",
+			"CREATE USER alice IDENTIFIED BY '***'",
+			[]string{"s3cr3t"},
+		},
+		{
+			`ALTER USER alice IDENTIFIED WITH sha256_hash BY 'abc\'d' SALT 'pepper'`,
+			"ALTER USER alice IDENTIFIED WITH sha256_hash BY '***' SALT '***'",
+			[]string{`abc\'d`, "abc'd", "pepper"},
+		},
+		{
+			"CREATE NAMED COLLECTION `s3` AS url = 'https://bucket', `secret` = 'key' NOT OVERRIDABLE",
+			"CREATE NAMED COLLECTION `s3` AS url = '***', `secret` = '***' NOT OVERRIDABLE",
+			[]string{"https://bucket", "key"},
+		},
+		{
+			"ALTER NAMED COLLECTION c SET password = 'p'",
+			"ALTER NAMED COLLECTION c SET password = '***'",
+			[]string{"p"},
+		},
+		{
+			"CREATE TABLE t (s String DEFAULT 'x') ENGINE = MySQL('db:3306', 'shop', 'orders', 'app', 'pw') COMMENT 'orders'",
+			"CREATE TABLE t (s String DEFAULT 'x') ENGINE = MySQL('***', '***', '***', '***', '***') COMMENT 'orders'",
+			[]string{"db:3306", "shop", "orders", "app", "pw"},
+		},
+		{
+			"CREATE TABLE t (s String) ENGINE = S3(conf, access_key_id = 'AKIA', format = 'CSV')",
+			"CREATE TABLE t (s String) ENGINE = S3(conf, access_key_id = '***', format = '***')",
+			[]string{"AKIA", "CSV"},
+		},
+		{
+			"CREATE TABLE t (s String) ENGINE = Kafka SETTINGS kafka_broker_list = 'k:9092', kafka_sasl_password = 'pw'",
+			"CREATE TABLE t (s String) ENGINE = Kafka SETTINGS kafka_broker_list = 'k:9092', kafka_sasl_password = '***'",
+			[]string{"pw"},
+		},
+		{
+			"CREATE TABLE t (s String) ENGINE = Kafka SETTINGS kafka_sasl_password = 12345, kafka_num_consumers = 2, nats_token = -1e3, rabbitmq_password = guest",
+			"CREATE TABLE t (s String) ENGINE = Kafka SETTINGS kafka_sasl_password = '***', kafka_num_consumers = 2, nats_token = -'***', rabbitmq_password = '***'",
+			[]string{"12345", "1e3", "guest"},
+		},
+		{
+			"INSERT INTO t SELECT * FROM remoteSecure('ch:9440', db.t, 'reader', 'pw') WHERE s = 'keep'",
+			"INSERT INTO t SELECT * FROM remoteSecure('***', db.t, '***', '***') WHERE s = 'keep'",
+			[]string{"ch:9440", "reader", "pw"},
+		},
+		{
+			"SELECT name FROM system.users WHERE name = 'alice'",
+			"SELECT name FROM system.users WHERE name = 'alice'",
+			nil,
+		},
+	} {
+		got, secrets := redactSQL(test.statement)
+		if got != test.want {
+			t.Errorf("redactSQL(%q) = %q, want %q", test.statement, got, test.want)
+		}
+		if !reflect.DeepEqual(secrets, test.secrets) {
+			t.Errorf("redactSQL(%q) secrets = %q, want %q", test.statement, secrets, test.secrets)
+		}
+	}
+}
+
+// fakeConn is a connection whose statements fail with an error echoing the
+// statement, the way server syntax errors quote it.
+type fakeConn struct {
+	driver.Conn
+	execs []string
+}
+
+func (c *fakeConn) Ping(context.Context) error { return nil }
+
+func (c *fakeConn) Exec(_ context.Context, query string, _ ...any) error {
+	c.execs = append(c.execs, query)
+	if len(c.execs) > 1 {
+		return errors.New("code: 62, message: Syntax error: failed at position 1 (" + query + ")")
+	}
+	return nil
+}
+
+func TestUserPasswordNotLogged(t *testing.T) {
+	const password = `it's a S3cret\pw`
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	conn := &fakeConn{}
+	r := &clickhouseUserResource{client: &providerClient{conn: conn, server: &serverInfo{}}}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	plan := tfsdk.Plan{
+		Schema: schemaResp.Schema,
+		Raw: tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"username":       tftypes.NewValue(tftypes.String, "alice"),
+			"password":       tftypes.NewValue(tftypes.String, password),
+			"query_settings": tftypes.NewValue(objectType.AttributeTypes["query_settings"], nil),
+			"timeouts":       tftypes.NewValue(objectType.AttributeTypes["timeouts"], nil),
+		}),
+	}
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: plan.Raw}
+
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create() diagnostics: %v", createResp.Diagnostics)
+	}
+
+	updateResp := resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state}, &updateResp)
+	if !updateResp.Diagnostics.HasError() {
+		t.Fatal("Update() did not report the statement error")
+	}
+
+	if len(conn.execs) != 2 || !strings.Contains(conn.execs[0], "IDENTIFIED BY 'it\\'s a S3cret\\\\pw'") {
+		t.Fatalf("executed %q", conn.execs)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var statements []map[string]any
+	for _, entry := range entries {
+		if entry["@message"] == "Executed ClickHouse statement" {
+			statements = append(statements, entry)
+		}
+	}
+	if len(statements) != 2 {
+		t.Fatalf("logged %d statements, want 2:\n%s", len(statements), output.String())
+	}
+	for i, operation := range []string{"create", "update"} {
+		entry := statements[i]
+		if entry["resource_type"] != "clickhouse_user" || entry["operation"] != operation || entry["duration_ms"] == nil {
+			t.Errorf("statement %d logged with fields %v", i, entry)
+		}
+		if !strings.Contains(entry["statement"].(string), "IDENTIFIED BY '***'") {
+			t.Errorf("statement %d logged as %q", i, entry["statement"])
+		}
+	}
+	if statements[1]["error"] == nil {
+		t.Error("the failed statement was logged without its error")
+	}
+
+	for _, leaked := range []string{password, "S3cret", `S3cret\\pw`} {
+		if strings.Contains(output.String(), leaked) {
+			t.Errorf("log output contains the password fragment %q:\n%s", leaked, output.String())
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"syscall"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// pingTimeout bounds the connectivity check of Configure.
+const pingTimeout = 30 * time.Second
+
+// authenticationExceptionCodes are the server error codes of rejected
+// credentials: UNKNOWN_USER, WRONG_PASSWORD, REQUIRED_PASSWORD and
+// AUTHENTICATION_FAILED.
+var authenticationExceptionCodes = map[int32]bool{192: true, 193: true, 194: true, 516: true}
+
+// skipPingHint closes every connectivity diagnostic.
+const skipPingHint = "\n\nTo plan without a connection to the server, set skip_ping to true in the provider configuration."
+
+// addPingError adds a diagnostic telling whether resolving the host, opening
+// the TCP connection, the TLS handshake or the authentication failed.
+func addPingError(diags *diag.Diagnostics, host string, err error) {
+	var (
+		summary, detail string
+		dnsErr          *net.DNSError
+		exception       *clickhouse.Exception
+		opErr           *net.OpError
+	)
+	switch {
+	case errors.As(err, &dnsErr):
+		summary = "Unable to Resolve ClickHouse Host"
+		detail = "The provider cannot resolve the address of " + host + ". " +
+			"Check the host value and the DNS configuration of the machine running Terraform."
+	case errors.As(err, &exception) && authenticationExceptionCodes[exception.Code]:
+		summary = "ClickHouse Authentication Failed"
+		detail = "The ClickHouse server at " + host + " rejected the credentials. " +
+			"Check the username, password, password_file, access_token or client certificate."
+	case isTLSError(err):
+		summary = "Unable to Establish a TLS Connection to ClickHouse"
+		detail = "The TLS handshake with " + host + " failed. Check that the port serves TLS, " +
+			"that ca_cert_file holds the authority that signed the server certificate, " +
+			"and that the server accepts the client certificate."
+	case errors.As(err, &opErr) || errors.Is(err, syscall.ECONNREFUSED):
+		summary = "Unable to Connect to ClickHouse Server"
+		detail = "The provider cannot open a TCP connection to " + host + ". " +
+			"Check the host and port, that the server is running and that no firewall blocks the connection."
+	default:
+		summary = "Unable to Ping ClickHouse Server"
+		detail = "The provider connected to " + host + " but the server did not answer the ping."
+	}
+	diags.AddError(summary, detail+"\n\nClickHouse Client Error: "+err.Error()+skipPingHint)
+}
+
+// isTLSError reports whether err comes from a failed TLS handshake, on
+// either side of the connection.
+func isTLSError(err error) bool {
+	var (
+		verifyErr    *tls.CertificateVerificationError
+		headerErr    tls.RecordHeaderError
+		alertErr     tls.AlertError
+		authorityErr x509.UnknownAuthorityError
+		hostErr      x509.HostnameError
+		invalidErr   x509.CertificateInvalidError
+		opErr        *net.OpError
+	)
+	return errors.As(err, &verifyErr) ||
+		errors.As(err, &headerErr) ||
+		errors.As(err, &alertErr) ||
+		errors.As(err, &authorityErr) ||
+		errors.As(err, &hostErr) ||
+		errors.As(err, &invalidErr) ||
+		errors.As(err, &opErr) && opErr.Op == "remote error"
+}
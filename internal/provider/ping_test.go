@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestAddPingError(t *testing.T) {
+	for _, test := range []struct {
+		err  error
+		want string
+	}{
+		{
+			&net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "ch.invalid", IsNotFound: true}},
+			"Unable to Resolve ClickHouse Host",
+		},
+		{
+			&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connect: connection refused")},
+			"Unable to Connect to ClickHouse Server",
+		},
+		{
+			fmt.Errorf("tls: failed to verify certificate: %w", x509.UnknownAuthorityError{}),
+			"Unable to Establish a TLS Connection to ClickHouse",
+		},
+		{
+			&net.OpError{Op: "remote error", Err: errors.New("tls: bad certificate")},
+			"Unable to Establish a TLS Connection to ClickHouse",
+		},
+		{
+			&clickhouse.Exception{Code: 516, Name: "DB::Exception", Message: "alice: Authentication failed"},
+			"ClickHouse Authentication Failed",
+		},
+		{
+			&clickhouse.Exception{Code: 81, Name: "DB::Exception", Message: "Database missing does not exist"},
+			"Unable to Ping ClickHouse Server",
+		},
+	} {
+		var diags diag.Diagnostics
+		addPingError(&diags, "ch:9000", test.err)
+		if len(diags) != 1 || diags[0].Summary() != test.want {
+			t.Errorf("addPingError(%v) = %v, want %q", test.err, diags, test.want)
+			continue
+		}
+		if !strings.Contains(diags[0].Detail(), test.err.Error()) || !strings.Contains(diags[0].Detail(), "skip_ping") {
+			t.Errorf("addPingError(%v) detail = %q", test.err, diags[0].Detail())
+		}
+	}
+}
+
+// configureProvider runs Configure with the given provider attributes; the
+// others are null.
+func configureProvider(t *testing.T, values map[string]tftypes.Value) provider.ConfigureResponse {
+	t.Helper()
+	ctx := context.Background()
+	p := &clickhouseProvider{}
+
+	var schemaResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for name, value := range values {
+		attributes[name] = value
+	}
+
+	var resp provider.ConfigureResponse
+	p.Configure(ctx, provider.ConfigureRequest{Config: tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(objectType, attributes),
+	}}, &resp)
+	return resp
+}
+
+func TestConfigurePing(t *testing.T) {
+	for _, name := range []string{"CLICKHOUSE_HOST", "CLICKHOUSE_USERNAME", "CLICKHOUSE_PASSWORD", "CLICKHOUSE_PASSWORD_FILE", "CLICKHOUSE_ACCESS_TOKEN"} {
+		t.Setenv(name, "")
+	}
+
+	// A port nothing listens on.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedAddr := listener.Addr().String()
+	listener.Close()
+
+	resp := configureProvider(t, map[string]tftypes.Value{"host": tftypes.NewValue(tftypes.String, closedAddr)})
+	if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Unable to Connect to ClickHouse Server" {
+		t.Errorf("Configure() with a closed port = %v", resp.Diagnostics)
+	}
+
+	resp = configureProvider(t, map[string]tftypes.Value{
+		"host":      tftypes.NewValue(tftypes.String, closedAddr),
+		"skip_ping": tftypes.NewValue(tftypes.Bool, true),
+	})
+	if resp.Diagnostics.HasError() || resp.ResourceData == nil {
+		t.Errorf("Configure() with skip_ping = %v", resp.Diagnostics)
+	}
+
+	resp = configureProvider(t, map[string]tftypes.Value{"host": tftypes.NewValue(tftypes.String, tftypes.UnknownValue)})
+	if resp.Diagnostics.HasError() || resp.ResourceData == nil {
+		t.Errorf("Configure() with an unknown host = %v", resp.Diagnostics)
+	}
+
+	// A server that does not speak TLS.
+	listener, err = net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Write([]byte("HTTP/1.0 400 Bad Request\r\n\r\n"))
+			conn.Close()
+		}
+	}()
+
+	resp = configureProvider(t, map[string]tftypes.Value{
+		"host":   tftypes.NewValue(tftypes.String, listener.Addr().String()),
+		"secure": tftypes.NewValue(tftypes.Bool, true),
+	})
+	if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Unable to Establish a TLS Connection to ClickHouse" {
+		t.Errorf("Configure() against a plain TCP server = %v", resp.Diagnostics)
+	}
+}
+
+func TestDistributedTablePlanWithoutServer(t *testing.T) {
+	ctx := context.Background()
+	r := &clickhouseDistributedTableResource{client: &fakeClient{server: &serverInfo{}}}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+	attributes["cluster"] = tftypes.NewValue(tftypes.String, "main")
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)}
+
+	resp := resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Errorf("ModifyPlan() without a contacted server = %v", resp.Diagnostics)
+	}
+}
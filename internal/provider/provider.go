@@ -2,7 +2,9 @@ package provider
 
 import (
 	"context"
+	"crypto/tls"
 	"os"
+	"time"
 
 	"github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -11,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -37,9 +40,21 @@ type clickhouseProvider struct {
 
 // clickhouseProviderModel maps provider schema data to a Go type.
 type clickhouseProviderModel struct {
-	Host     types.String `tfsdk:"host"`
-	Username types.String `tfsdk:"username"`
-	Password types.String `tfsdk:"password"`
+	Host           types.String `tfsdk:"host"`
+	Username       types.String `tfsdk:"username"`
+	Password       types.String `tfsdk:"password"`
+	PasswordFile   types.String `tfsdk:"password_file"`
+	AccessToken    types.String `tfsdk:"access_token"`
+	Secure         types.Bool   `tfsdk:"secure"`
+	CACertFile     types.String `tfsdk:"ca_cert_file"`
+	ClientCertFile types.String `tfsdk:"client_cert_file"`
+	ClientKeyFile  types.String `tfsdk:"client_key_file"`
+	Cluster        types.String `tfsdk:"cluster"`
+	MaxRetries     types.Int64  `tfsdk:"max_retries"`
+	RetryTimeout   types.String `tfsdk:"retry_timeout"`
+	WaitForDDL     types.Bool   `tfsdk:"wait_for_distributed_ddl"`
+	Settings       types.Map    `tfsdk:"settings"`
+	SkipPing       types.Bool   `tfsdk:"skip_ping"`
 }
 
 // Metadata returns the provider type name.
@@ -54,17 +69,87 @@ func (p *clickhouseProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"host": schema.StringAttribute{
-				Required:    true,
-				Description: "The hostname or IP address of the ClickHouse server.",
+				Optional: true,
+				Description: "The hostname or IP address of the ClickHouse server. " +
+					"Can also be set with the CLICKHOUSE_HOST environment variable.",
 			},
 			"username": schema.StringAttribute{
-				Required:    true,
-				Description: "The username for accessing the ClickHouse server.",
+				Optional: true,
+				Description: "The username for accessing the ClickHouse server. Defaults to default. " +
+					"Can also be set with the CLICKHOUSE_USERNAME environment variable.",
 			},
 			"password": schema.StringAttribute{
-				Required:    true,
-				Sensitive:   true,
-				Description: "The password for accessing the ClickHouse server.",
+				Optional:  true,
+				Sensitive: true,
+				Description: "The password for accessing the ClickHouse server. Leave it empty for passwordless users " +
+					"and for users authenticated by client_cert_file. " +
+					"Can also be set with the CLICKHOUSE_PASSWORD environment variable.",
+			},
+			"password_file": schema.StringAttribute{
+				Optional: true,
+				Description: "The path of a file holding the password, such as a mounted secret. A trailing newline is ignored. " +
+					"Conflicts with password. Can also be set with the CLICKHOUSE_PASSWORD_FILE environment variable.",
+			},
+			"access_token": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				Description: "A JSON Web Token authenticating the user instead of username and password, as supported by " +
+					"ClickHouse Cloud. Requires TLS. Can also be set with the CLICKHOUSE_ACCESS_TOKEN environment variable.",
+			},
+			"secure": schema.BoolAttribute{
+				Optional: true,
+				Description: "Connect over TLS. Defaults to true when access_token, ca_cert_file or client_cert_file is set, " +
+					"false otherwise.",
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "The path of a PEM file with the certificate authorities that verify the server instead of the system roots.",
+			},
+			"client_cert_file": schema.StringAttribute{
+				Optional: true,
+				Description: "The path of a PEM client certificate presented to the server. Without a password, the server " +
+					"authenticates the user by this certificate. Requires client_key_file.",
+			},
+			"client_key_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "The path of the PEM private key of client_cert_file.",
+			},
+			"cluster": schema.StringAttribute{
+				Optional: true,
+				Description: "The cluster DDL statements run ON CLUSTER when a resource does not set on_cluster. " +
+					"It applies to clickhouse_database, clickhouse_table, clickhouse_distributed_table and clickhouse_function, " +
+					"and to clickhouse_sql where a script holds the {on_cluster} placeholder. Users, settings profiles, quotas, " +
+					"row policies and named collections are created on the connected server only. " +
+					"Can also be set with the CLICKHOUSE_CLUSTER environment variable.",
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional: true,
+				Description: "How many times a read-only statement failing with a transient error, such as a network timeout, " +
+					"TOO_MANY_SIMULTANEOUS_QUERIES or a lost ZooKeeper session, is retried. Statements that change state are " +
+					"only retried when the server could not be reached or refused them before running them, such as with " +
+					"TOO_MANY_SIMULTANEOUS_QUERIES; DDL statements are also retried after a lost ZooKeeper session. " +
+					"Defaults to 3; 0 disables retries.",
+			},
+			"retry_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "The longest time a statement is retried for, as a duration such as 90s or 5m. Defaults to 5m.",
+			},
+			"wait_for_distributed_ddl": schema.BoolAttribute{
+				Optional: true,
+				Description: "Wait until system.distributed_ddl_queue shows ON CLUSTER DDL finished on every host of the cluster, " +
+					"including inactive ones, before saving state. Defaults to false.",
+			},
+			"settings": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Settings applied to every query the provider runs, such as allow_experimental_* flags, " +
+					"distributed_ddl_output_mode or mutations_sync. The query_settings of a resource take precedence.",
+			},
+			"skip_ping": schema.BoolAttribute{
+				Optional: true,
+				Description: "Do not contact the server when the provider is configured, so that plans work offline. " +
+					"Connection errors then surface in the first resource or data source that runs a query, " +
+					"and features that depend on the server version are not checked at plan time. Defaults to false.",
 			},
 		},
 	}
@@ -82,6 +167,9 @@ func (p *clickhouseProvider) Configure(ctx context.Context, req provider.Configu
 	host := os.Getenv("CLICKHOUSE_HOST")
 	username := os.Getenv("CLICKHOUSE_USERNAME")
 	password := os.Getenv("CLICKHOUSE_PASSWORD")
+	passwordFile := os.Getenv("CLICKHOUSE_PASSWORD_FILE")
+	accessToken := os.Getenv("CLICKHOUSE_ACCESS_TOKEN")
+	cluster := os.Getenv("CLICKHOUSE_CLUSTER")
 
 	if !config.Host.IsNull() {
 		host = config.Host.ValueString()
@@ -91,11 +179,44 @@ func (p *clickhouseProvider) Configure(ctx context.Context, req provider.Configu
 		username = config.Username.ValueString()
 	}
 
+	if !config.Password.IsNull() && !config.PasswordFile.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password_file"),
+			"Conflicting ClickHouse Password",
+			"The password and password_file values cannot both be set.",
+		)
+	}
+
 	if !config.Password.IsNull() {
-		password = config.Password.ValueString()
+		password, passwordFile = config.Password.ValueString(), ""
+	}
+
+	if !config.PasswordFile.IsNull() {
+		password, passwordFile = "", config.PasswordFile.ValueString()
+	}
+
+	if passwordFile != "" {
+		var err error
+		password, err = readSecretFile(passwordFile)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("password_file"),
+				"Unable to Read ClickHouse Password File",
+				"The provider cannot read the ClickHouse password from password_file or the CLICKHOUSE_PASSWORD_FILE "+
+					"environment variable: "+err.Error(),
+			)
+		}
+	}
+
+	if !config.AccessToken.IsNull() {
+		accessToken = config.AccessToken.ValueString()
+	}
+
+	if !config.Cluster.IsNull() {
+		cluster = config.Cluster.ValueString()
 	}
 
-	if host == "" {
+	if host == "" && !config.Host.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("host"),
 			"Missing ClickHouse Host",
@@ -105,37 +226,87 @@ func (p *clickhouseProvider) Configure(ctx context.Context, req provider.Configu
 		)
 	}
 
-	if username == "" {
+	if accessToken != "" && (!config.Username.IsNull() || !config.Password.IsNull() || !config.PasswordFile.IsNull()) {
 		resp.Diagnostics.AddAttributeError(
-			path.Root("username"),
-			"Missing ClickHouse Username",
-			"The provider cannot create the ClickHouse client as there is a missing or empty value for the ClickHouse username. "+
-				"Set the username value in the configuration or use the CLICKHOUSE_USERNAME environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+			path.Root("access_token"),
+			"Conflicting ClickHouse Credentials",
+			"The access_token value authenticates the user on its own and cannot be combined with username, "+
+				"password or password_file.",
 		)
 	}
 
-	if password == "" {
+	secure := accessToken != "" || !config.CACertFile.IsNull() || !config.ClientCertFile.IsNull()
+	if !config.Secure.IsNull() {
+		secure = config.Secure.ValueBool()
+	}
+
+	if accessToken != "" && !secure {
 		resp.Diagnostics.AddAttributeError(
-			path.Root("password"),
-			"Missing ClickHouse Password",
-			"The provider cannot create the ClickHouse client as there is a missing or empty value for the ClickHouse password. "+
-				"Set the password value in the configuration or use the CLICKHOUSE_PASSWORD environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+			path.Root("secure"),
+			"Insecure ClickHouse Access Token",
+			"The access_token value is only sent over TLS. Remove secure = false from the configuration.",
+		)
+	}
+
+	var tlsConfig *tls.Config
+	if secure {
+		var err error
+		tlsConfig, err = newTLSConfig(
+			config.CACertFile.ValueString(),
+			config.ClientCertFile.ValueString(),
+			config.ClientKeyFile.ValueString(),
+		)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid ClickHouse TLS Configuration",
+				"The provider cannot load the certificates of ca_cert_file, client_cert_file and client_key_file: "+err.Error(),
+			)
+		}
+	} else if !config.CACertFile.IsNull() || !config.ClientCertFile.IsNull() || !config.ClientKeyFile.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("secure"),
+			"Conflicting ClickHouse TLS Configuration",
+			"The ca_cert_file, client_cert_file and client_key_file values require secure to be true.",
 		)
 	}
 
+	retry := defaultRetryPolicy(defaultMaxRetries, defaultRetryTimeout)
+	if !config.MaxRetries.IsNull() {
+		if config.MaxRetries.ValueInt64() < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid Max Retries",
+				"The max_retries value must not be negative.",
+			)
+		}
+		retry.maxRetries = int(config.MaxRetries.ValueInt64())
+	}
+
+	if !config.RetryTimeout.IsNull() {
+		timeout, err := time.ParseDuration(config.RetryTimeout.ValueString())
+		if err != nil || timeout <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_timeout"),
+				"Invalid Retry Timeout",
+				"The retry_timeout value must be a positive duration such as 90s or 5m, got "+config.RetryTimeout.String()+".",
+			)
+		}
+		retry.timeout = timeout
+	}
+
+	settings := clickhouse.Settings{}
+	for name, value := range stringsFromMap(ctx, config.Settings, &resp.Diagnostics) {
+		settings[name] = value
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	client, err := clickhouse.Open(&clickhouse.Options{
 		Addr: []string{host},
-		Auth: clickhouse.Auth{
-			Database: "default",
-			Username: username,
-			Password: password,
-		},
+		Auth: authOptions(username, password, accessToken),
+		TLS:  tlsConfig,
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -147,8 +318,44 @@ func (p *clickhouseProvider) Configure(ctx context.Context, req provider.Configu
 		return
 	}
 
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	data := &providerClient{
+		conn:     client,
+		cluster:  cluster,
+		retry:    retry,
+		waitDDL:  config.WaitForDDL.ValueBool(),
+		settings: settings,
+	}
+	resp.DataSourceData = data
+	resp.ResourceData = data
+
+	// Values that depend on other resources are unknown until they are
+	// applied, so the server cannot be contacted while planning them.
+	unknown := config.Host.IsUnknown() || config.Username.IsUnknown() || config.Password.IsUnknown() ||
+		config.PasswordFile.IsUnknown() || config.AccessToken.IsUnknown() || config.Secure.IsUnknown() ||
+		config.CACertFile.IsUnknown() || config.ClientCertFile.IsUnknown() || config.ClientKeyFile.IsUnknown()
+	if unknown || config.SkipPing.ValueBool() {
+		tflog.Debug(ctx, "Not contacting the ClickHouse server", map[string]any{"unknown_values": unknown})
+		data.server = &serverInfo{}
+		return
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+	if err := client.Ping(pingCtx); err != nil {
+		addPingError(&resp.Diagnostics, host, err)
+		return
+	}
+
+	data.server, err = detectServerInfo(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to Detect ClickHouse Server Version",
+			"The provider could not read the version of the ClickHouse server, so features that depend on it "+
+				"are not checked at plan time and unsupported statements will only fail when applied.\n\n"+
+				"ClickHouse Client Error: "+err.Error(),
+		)
+		data.server = &serverInfo{}
+	}
 }
 
 // DataSources defines the data sources implemented in the provider.
@@ -164,6 +371,45 @@ func (p *clickhouseProvider) DataSources(_ context.Context) []func() datasource.
 		func() datasource.DataSource {
 			return &clickhouseRolesDataSource{}
 		},
+		func() datasource.DataSource {
+			return &clickhouseQueryDataSource{}
+		},
+		func() datasource.DataSource {
+			return &clickhouseUserDataSource{}
+		},
+		func() datasource.DataSource {
+			return &clickhouseDatabaseDataSource{}
+		},
+		func() datasource.DataSource {
+			return &clickhouseRoleDataSource{}
+		},
+		func() datasource.DataSource {
+			return &clickhouseTablesDataSource{}
+		},
+		func() datasource.DataSource {
+			return &clickhouseColumnsDataSource{}
+		},
+		func() datasource.DataSource {
+			return &clickhouseGrantsDataSource{}
+		},
+		func() datasource.DataSource {
+			return &clickhouseClustersDataSource{}
+		},
+		func() datasource.DataSource {
+			return &clickhouseMacrosDataSource{}
+		},
+		func() datasource.DataSource {
+			return &clickhouseReplicasDataSource{}
+		},
+		func() datasource.DataSource {
+			return &clickhouseDisksDataSource{}
+		},
+		func() datasource.DataSource {
+			return &clickhouseStoragePoliciesDataSource{}
+		},
+		func() datasource.DataSource {
+			return &clickhouseServerInfoDataSource{}
+		},
 	}
 }
 
@@ -176,5 +422,29 @@ func (p *clickhouseProvider) Resources(_ context.Context) []func() resource.Reso
 		func() resource.Resource {
 			return &clickhouseDatabaseResource{}
 		},
+		func() resource.Resource {
+			return &clickhouseSettingsProfileResource{}
+		},
+		func() resource.Resource {
+			return &clickhouseQuotaResource{}
+		},
+		func() resource.Resource {
+			return &clickhouseRowPolicyResource{}
+		},
+		func() resource.Resource {
+			return &clickhouseNamedCollectionResource{}
+		},
+		func() resource.Resource {
+			return &clickhouseFunctionResource{}
+		},
+		func() resource.Resource {
+			return &clickhouseDistributedTableResource{}
+		},
+		func() resource.Resource {
+			return &clickhouseTableResource{}
+		},
+		func() resource.Resource {
+			return &clickhouseSQLResource{}
+		},
 	}
 }
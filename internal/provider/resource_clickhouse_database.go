@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -18,12 +20,15 @@ var (
 
 // clickhousedatabaseResource is the resource implementation.
 type clickhouseDatabaseResource struct {
-	client clickhouse.Conn
+	client clickhouseClient
 }
 
 // clickhousedatabaseResourceModel maps the resource schema data.
 type clickhouseDatabaseResourceModel struct {
-	Database types.String `tfsdk:"database"`
+	Database      types.String   `tfsdk:"database"`
+	OnCluster     types.String   `tfsdk:"on_cluster"`
+	QuerySettings types.Map      `tfsdk:"query_settings"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Metadata returns the resource type name.
@@ -32,19 +37,32 @@ func (r *clickhouseDatabaseResource) Metadata(_ context.Context, _ resource.Meta
 }
 
 // Schema defines the schema for the resource.
-func (r *clickhouseDatabaseResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *clickhouseDatabaseResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"database": schema.StringAttribute{
 				Required:    true,
 				Description: "The name of the ClickHouse database.",
 			},
+			"on_cluster": schema.StringAttribute{
+				Optional:    true,
+				Description: "The cluster to create the database on. Defaults to the provider cluster.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"query_settings": querySettingsAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
 		},
 	}
 }
 
 // Create handles the creation of the resource.
 func (r *clickhouseDatabaseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withOperation(ctx, "clickhouse_database", "create")
+
 	var plan clickhouseDatabaseResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -52,12 +70,22 @@ func (r *clickhouseDatabaseResource) Create(ctx context.Context, req resource.Cr
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, createTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, plan.QuerySettings, &resp.Diagnostics)
+
 	createDatabseQuery := fmt.Sprintf(
-		"CREATE DATABASE %s",
+		"CREATE DATABASE %s%s",
 		plan.Database.ValueString(),
+		onClusterClause(plan.OnCluster, r.client.Cluster()),
 	)
 
-	if err := r.client.Exec(ctx, createDatabseQuery); err != nil {
+	if err := r.client.ExecDDL(ctx, createDatabseQuery); err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating ClickHouse database",
 			"Could not create ClickHouse database, unexpected error: "+err.Error(),
@@ -71,6 +99,8 @@ func (r *clickhouseDatabaseResource) Create(ctx context.Context, req resource.Cr
 
 // Read handles reading the resource data.
 func (r *clickhouseDatabaseResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withOperation(ctx, "clickhouse_database", "read")
+
 	var state clickhouseDatabaseResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -78,6 +108,15 @@ func (r *clickhouseDatabaseResource) Read(ctx context.Context, req resource.Read
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, readTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, state.QuerySettings, &resp.Diagnostics)
+
 	// Properly format the query to check if the database exists, enclosing the databasename in single quotes
 	query := fmt.Sprintf("SELECT count() > 0 FROM system.databases WHERE name='%s'", state.Database.ValueString())
 	var exists bool
@@ -105,19 +144,34 @@ func (r *clickhouseDatabaseResource) Read(ctx context.Context, req resource.Read
 
 // Update handles updating the resource.
 // Update handles updating the resource for a database.
-// In this case, we simply return an error indicating that updates are not permitted.
+// Only the timeouts can change in place; renaming the database returns an error.
 func (r *clickhouseDatabaseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// Create a new diagnostics entry indicating that updates are not permitted
-	resp.Diagnostics.AddError(
-		"Update Not Permitted",
-		"Updating an existing ClickHouse database is not permitted. Please recreate the database instead of attempting to update it.",
-	)
+	ctx = withOperation(ctx, "clickhouse_database", "update")
 
-	// No further logic is needed as we're just returning an error message.
+	var plan, state clickhouseDatabaseResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Database.Equal(state.Database) {
+		// Create a new diagnostics entry indicating that updates are not permitted
+		resp.Diagnostics.AddError(
+			"Update Not Permitted",
+			"Updating an existing ClickHouse database is not permitted. Please recreate the database instead of attempting to update it.",
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
 }
 
 // Delete handles deleting the resource.
 func (r *clickhouseDatabaseResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withOperation(ctx, "clickhouse_database", "delete")
+
 	var state clickhouseDatabaseResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -125,9 +179,22 @@ func (r *clickhouseDatabaseResource) Delete(ctx context.Context, req resource.De
 		return
 	}
 
-	deleteDatabaseQuery := fmt.Sprintf("DROP DATABASE IF EXISTS %s", state.Database.ValueString())
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, deleteTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, state.QuerySettings, &resp.Diagnostics)
+
+	deleteDatabaseQuery := fmt.Sprintf(
+		"DROP DATABASE IF EXISTS %s%s",
+		state.Database.ValueString(),
+		onClusterClause(state.OnCluster, r.client.Cluster()),
+	)
 
-	if err := r.client.Exec(ctx, deleteDatabaseQuery); err != nil {
+	if err := r.client.ExecDDL(ctx, deleteDatabaseQuery); err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting ClickHouse database",
 			"Could not delete ClickHouse database, unexpected error: "+err.Error(),
@@ -142,11 +209,11 @@ func (r *clickhouseDatabaseResource) Configure(ctx context.Context, req resource
 		return
 	}
 
-	client, ok := req.ProviderData.(clickhouse.Conn)
+	client, ok := req.ProviderData.(clickhouseClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			"Expected clickhouse.Conn, got something else",
+			"Expected clickhouseClient, got something else",
 		)
 		return
 	}
@@ -0,0 +1,619 @@
+package provider
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &clickhouseDistributedTableResource{}
+	_ resource.ResourceWithConfigure      = &clickhouseDistributedTableResource{}
+	_ resource.ResourceWithImportState    = &clickhouseDistributedTableResource{}
+	_ resource.ResourceWithModifyPlan     = &clickhouseDistributedTableResource{}
+	_ resource.ResourceWithValidateConfig = &clickhouseDistributedTableResource{}
+)
+
+// distributedSettings lists the settings accepted by the Distributed engine.
+var distributedSettings = []string{
+	"fsync_after_insert",
+	"fsync_directories",
+	"skip_unavailable_shards",
+	"bytes_to_throw_insert",
+	"bytes_to_delay_insert",
+	"max_delay_to_insert",
+	"background_insert_batch",
+	"background_insert_split_batch_on_failure",
+	"background_insert_sleep_time_ms",
+	"background_insert_max_sleep_time_ms",
+	"flush_on_detach",
+	"monitor_batch_inserts",
+	"monitor_split_batch_on_failure",
+	"monitor_sleep_time_ms",
+	"monitor_max_sleep_time_ms",
+}
+
+// tableColumnAttrTypes describes the objects of a computed columns list.
+var tableColumnAttrTypes = map[string]attr.Type{
+	"name": types.StringType,
+	"type": types.StringType,
+}
+
+// clickhouseDistributedTableResource is the resource implementation.
+type clickhouseDistributedTableResource struct {
+	client clickhouseClient
+}
+
+// clickhouseDistributedTableResourceModel maps the resource schema data.
+type clickhouseDistributedTableResourceModel struct {
+	Database       types.String   `tfsdk:"database"`
+	Name           types.String   `tfsdk:"name"`
+	OnCluster      types.String   `tfsdk:"on_cluster"`
+	Cluster        types.String   `tfsdk:"cluster"`
+	RemoteDatabase types.String   `tfsdk:"remote_database"`
+	RemoteTable    types.String   `tfsdk:"remote_table"`
+	ShardingKey    types.String   `tfsdk:"sharding_key"`
+	PolicyName     types.String   `tfsdk:"policy_name"`
+	Settings       types.Map      `tfsdk:"settings"`
+	Comment        types.String   `tfsdk:"comment"`
+	Columns        types.List     `tfsdk:"columns"`
+	QuerySettings  types.Map      `tfsdk:"query_settings"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+// tableName returns the quoted database.table name of the table.
+func (m *clickhouseDistributedTableResourceModel) tableName() string {
+	return quoteIdentifier(m.Database.ValueString()) + "." + quoteIdentifier(m.Name.ValueString())
+}
+
+// Metadata returns the resource type name.
+func (r *clickhouseDistributedTableResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "clickhouse_distributed_table"
+}
+
+// Schema defines the schema for the resource.
+func (r *clickhouseDistributedTableResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	requiresReplace := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Required:      true,
+				Description:   "The database of the Distributed table.",
+				PlanModifiers: requiresReplace,
+			},
+			"name": schema.StringAttribute{
+				Required:      true,
+				Description:   "The name of the Distributed table.",
+				PlanModifiers: requiresReplace,
+			},
+			"on_cluster": schema.StringAttribute{
+				Optional:      true,
+				Description:   "The cluster to run the DDL on, usually the same as cluster. Defaults to the provider cluster.",
+				PlanModifiers: requiresReplace,
+			},
+			"cluster": schema.StringAttribute{
+				Required:      true,
+				Description:   "The cluster the Distributed engine sends queries to. It must exist in system.clusters.",
+				PlanModifiers: requiresReplace,
+			},
+			"remote_database": schema.StringAttribute{
+				Required:      true,
+				Description:   "The database of the local table on each shard.",
+				PlanModifiers: requiresReplace,
+			},
+			"remote_table": schema.StringAttribute{
+				Required:      true,
+				Description:   "The local table on each shard. Its columns are copied to the Distributed table.",
+				PlanModifiers: requiresReplace,
+			},
+			"sharding_key": schema.StringAttribute{
+				Optional:      true,
+				Description:   "The sharding key expression, e.g. rand() or cityHash64(user_id).",
+				PlanModifiers: requiresReplace,
+			},
+			"policy_name": schema.StringAttribute{
+				Optional:      true,
+				Description:   "The storage policy used for the temporary files of background inserts.",
+				PlanModifiers: requiresReplace,
+			},
+			"settings": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Distributed engine settings such as fsync_after_insert or skip_unavailable_shards.",
+			},
+			"comment": schema.StringAttribute{
+				Optional:    true,
+				Description: "The comment of the table.",
+			},
+			"columns": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The columns copied from the local table.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the column.",
+						},
+						"type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The type of the column.",
+						},
+					},
+				},
+			},
+			"query_settings": querySettingsAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+// ValidateConfig checks the engine settings against the ones Distributed
+// supports.
+func (r *clickhouseDistributedTableResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config clickhouseDistributedTableResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for name := range stringsFromMap(ctx, config.Settings, &resp.Diagnostics) {
+		valid := false
+		for _, setting := range distributedSettings {
+			if name == setting {
+				valid = true
+			}
+		}
+		if !valid {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("settings").AtMapKey(name),
+				"Unknown Distributed Setting",
+				"The Distributed engine does not support the setting "+name+". Supported settings are: "+strings.Join(distributedSettings, ", "),
+			)
+		}
+	}
+}
+
+// ModifyPlan checks that the cluster exists before the table is planned.
+// When the provider did not contact the server, because of skip_ping or
+// unknown connection values, the check is left to apply time.
+func (r *clickhouseDistributedTableResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil || !r.client.Server().known() {
+		return
+	}
+
+	var cluster types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("cluster"), &cluster)...)
+	if resp.Diagnostics.HasError() || cluster.IsUnknown() {
+		return
+	}
+
+	var exists bool
+	err := r.client.QueryRow(ctx, "SELECT count() > 0 FROM system.clusters WHERE cluster = ?", cluster.ValueString()).Scan(&exists)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading ClickHouse clusters",
+			"Could not check whether the cluster exists, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if !exists {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("cluster"),
+			"Cluster does not exist",
+			"The ClickHouse cluster "+cluster.ValueString()+" is not defined in system.clusters.",
+		)
+	}
+}
+
+// Create handles the creation of the resource.
+func (r *clickhouseDistributedTableResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withOperation(ctx, "clickhouse_distributed_table", "create")
+
+	var plan clickhouseDistributedTableResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, createTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, plan.QuerySettings, &resp.Diagnostics)
+
+	engineArgs := []string{
+		quoteString(plan.Cluster.ValueString()),
+		quoteString(plan.RemoteDatabase.ValueString()),
+		quoteString(plan.RemoteTable.ValueString()),
+	}
+	if !plan.ShardingKey.IsNull() {
+		engineArgs = append(engineArgs, plan.ShardingKey.ValueString())
+		if !plan.PolicyName.IsNull() {
+			engineArgs = append(engineArgs, quoteString(plan.PolicyName.ValueString()))
+		}
+	}
+
+	createTableQuery := "CREATE TABLE " + plan.tableName() + onClusterClause(plan.OnCluster, r.client.Cluster()) +
+		" AS " + quoteIdentifier(plan.RemoteDatabase.ValueString()) + "." + quoteIdentifier(plan.RemoteTable.ValueString()) +
+		" ENGINE = Distributed(" + strings.Join(engineArgs, ", ") + ")"
+
+	settings := stringsFromMap(ctx, plan.Settings, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(settings) > 0 {
+		createTableQuery += " SETTINGS " + strings.Join(settingAssignments(settingLiterals(settings)), ", ")
+	}
+
+	if err := r.client.ExecDDL(ctx, createTableQuery); err != nil {
+		addDDLError(&resp.Diagnostics, "Error creating ClickHouse distributed table", "Could not create ClickHouse distributed table", err)
+		return
+	}
+
+	if !plan.Comment.IsNull() {
+		commentQuery := "ALTER TABLE " + plan.tableName() + onClusterClause(plan.OnCluster, r.client.Cluster()) + " MODIFY COMMENT " + quoteString(plan.Comment.ValueString())
+		if err := r.client.ExecDDL(ctx, commentQuery); err != nil {
+			addDDLError(&resp.Diagnostics, "Error creating ClickHouse distributed table", "Could not set the comment of ClickHouse distributed table", err)
+			return
+		}
+	}
+
+	columns, err := readTableColumns(ctx, r.client, plan.Database.ValueString(), plan.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading ClickHouse distributed table",
+			"Could not read the columns of ClickHouse distributed table after creating it, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.Columns = columns
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// settingAssignments renders name = literal pairs in a stable order.
+func settingAssignments(literals map[string]string) []string {
+	names := make([]string, 0, len(literals))
+	for name := range literals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	assignments := make([]string, 0, len(names))
+	for _, name := range names {
+		assignments = append(assignments, name+" = "+literals[name])
+	}
+	return assignments
+}
+
+// Read handles reading the resource data.
+func (r *clickhouseDistributedTableResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withOperation(ctx, "clickhouse_distributed_table", "read")
+
+	var state clickhouseDistributedTableResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, readTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, state.QuerySettings, &resp.Diagnostics)
+
+	found, err := r.readTable(ctx, &state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading ClickHouse distributed table",
+			"Could not read ClickHouse distributed table, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// readTable refreshes the model from system.tables and system.columns. It
+// reports false when the table is gone.
+func (r *clickhouseDistributedTableResource) readTable(ctx context.Context, state *clickhouseDistributedTableResourceModel) (bool, error) {
+	rows, err := r.client.Query(ctx, `
+		SELECT engine_full, sharding_key, comment
+		FROM system.tables
+		WHERE database = ? AND name = ?`, state.Database.ValueString(), state.Name.ValueString())
+	if err != nil {
+		return false, err
+	}
+	found := false
+	var engineFull, shardingKey, comment string
+	for rows.Next() {
+		found = true
+		if err := rows.Scan(&engineFull, &shardingKey, &comment); err != nil {
+			rows.Close()
+			return false, err
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+	if !found {
+		return false, nil
+	}
+
+	columns, err := readTableColumns(ctx, r.client, state.Database.ValueString(), state.Name.ValueString())
+	if err != nil {
+		return false, err
+	}
+
+	if !sameExpression(state.ShardingKey.ValueString(), shardingKey) {
+		state.ShardingKey = types.StringValue(shardingKey)
+	}
+	settings := parseEngineSettings(engineFull)
+	if !state.Settings.IsNull() || len(settings) > 0 {
+		state.Settings = stringMapValue(state.Settings, settings)
+	}
+	if comment != "" || !state.Comment.IsNull() {
+		state.Comment = types.StringValue(comment)
+	}
+	state.Columns = columns
+	return true, nil
+}
+
+// readTableColumns lists the columns of a table as a list of name and type
+// objects.
+func readTableColumns(ctx context.Context, client clickhouseClient, database, table string) (types.List, error) {
+	rows, err := client.Query(ctx, "SELECT name, type FROM system.columns WHERE database = ? AND table = ? ORDER BY position", database, table)
+	if err != nil {
+		return types.ListNull(types.ObjectType{AttrTypes: tableColumnAttrTypes}), err
+	}
+	defer rows.Close()
+
+	var columns []attr.Value
+	for rows.Next() {
+		var name, columnType string
+		if err := rows.Scan(&name, &columnType); err != nil {
+			return types.ListNull(types.ObjectType{AttrTypes: tableColumnAttrTypes}), err
+		}
+		columns = append(columns, types.ObjectValueMust(tableColumnAttrTypes, map[string]attr.Value{
+			"name": types.StringValue(name),
+			"type": types.StringValue(columnType),
+		}))
+	}
+	if err := rows.Err(); err != nil {
+		return types.ListNull(types.ObjectType{AttrTypes: tableColumnAttrTypes}), err
+	}
+
+	return types.ListValueMust(types.ObjectType{AttrTypes: tableColumnAttrTypes}, columns), nil
+}
+
+// Update handles updating the resource. Only the engine settings and the
+// comment can change in place.
+func (r *clickhouseDistributedTableResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withOperation(ctx, "clickhouse_distributed_table", "update")
+
+	var plan, state clickhouseDistributedTableResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, updateTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, plan.QuerySettings, &resp.Diagnostics)
+
+	planSettings := stringsFromMap(ctx, plan.Settings, &resp.Diagnostics)
+	stateSettings := stringsFromMap(ctx, state.Settings, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var alterations []string
+	changed := map[string]string{}
+	for name, value := range planSettings {
+		if current, ok := stateSettings[name]; !ok || current != value {
+			changed[name] = value
+		}
+	}
+	if len(changed) > 0 {
+		alterations = append(alterations, "MODIFY SETTING "+strings.Join(settingAssignments(settingLiterals(changed)), ", "))
+	}
+	var reset []string
+	for name := range stateSettings {
+		if _, ok := planSettings[name]; !ok {
+			reset = append(reset, name)
+		}
+	}
+	if len(reset) > 0 {
+		sort.Strings(reset)
+		alterations = append(alterations, "RESET SETTING "+strings.Join(reset, ", "))
+	}
+	if !plan.Comment.Equal(state.Comment) {
+		alterations = append(alterations, "MODIFY COMMENT "+quoteString(plan.Comment.ValueString()))
+	}
+
+	for _, alteration := range alterations {
+		updateTableQuery := "ALTER TABLE " + plan.tableName() + onClusterClause(plan.OnCluster, r.client.Cluster()) + " " + alteration
+		if err := r.client.ExecDDL(ctx, updateTableQuery); err != nil {
+			addDDLError(&resp.Diagnostics, "Error updating ClickHouse distributed table", "Could not update ClickHouse distributed table", err)
+			return
+		}
+	}
+
+	columns, err := readTableColumns(ctx, r.client, plan.Database.ValueString(), plan.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading ClickHouse distributed table",
+			"Could not read the columns of ClickHouse distributed table after updating it, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.Columns = columns
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete handles deleting the resource.
+func (r *clickhouseDistributedTableResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withOperation(ctx, "clickhouse_distributed_table", "delete")
+
+	var state clickhouseDistributedTableResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, deleteTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, state.QuerySettings, &resp.Diagnostics)
+
+	deleteTableQuery := "DROP TABLE IF EXISTS " + state.tableName() + onClusterClause(state.OnCluster, r.client.Cluster())
+
+	if err := r.client.ExecDDL(ctx, deleteTableQuery); err != nil {
+		addDDLError(&resp.Diagnostics, "Error deleting ClickHouse distributed table", "Could not delete ClickHouse distributed table", err)
+		return
+	}
+}
+
+// Configure configures the resource with the provider data.
+func (r *clickhouseDistributedTableResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(clickhouseClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected clickhouseClient, got something else",
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ImportState imports an existing Distributed table from a database.table ID.
+func (r *clickhouseDistributedTableResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = withOperation(ctx, "clickhouse_distributed_table", "import")
+
+	database, name, found := strings.Cut(req.ID, ".")
+	if !found {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"The import ID must be written as database.table, got: "+req.ID,
+		)
+		return
+	}
+
+	var engine string
+	var engineArgs []string
+	rows, err := r.client.Query(ctx, "SELECT engine, engine_full FROM system.tables WHERE database = ? AND name = ?", database, name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing ClickHouse distributed table",
+			"Could not read ClickHouse distributed table, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var engineFull string
+		if err := rows.Scan(&engine, &engineFull); err != nil {
+			resp.Diagnostics.AddError(
+				"Error importing ClickHouse distributed table",
+				"Could not read ClickHouse distributed table, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		definition, _, _ := strings.Cut(engineFull, " SETTINGS ")
+		definition = strings.TrimSuffix(strings.TrimPrefix(definition, "Distributed("), ")")
+		engineArgs = splitTopLevel(definition)
+	}
+
+	if engine != "Distributed" || len(engineArgs) < 3 {
+		resp.Diagnostics.AddError(
+			"Distributed table does not exist",
+			"The ClickHouse table "+req.ID+" does not exist or does not use the Distributed engine.",
+		)
+		return
+	}
+
+	unquote := func(value string) string {
+		return strings.Trim(value, "'`")
+	}
+	state := clickhouseDistributedTableResourceModel{
+		Database:       types.StringValue(database),
+		Name:           types.StringValue(name),
+		OnCluster:      types.StringNull(),
+		Cluster:        types.StringValue(unquote(engineArgs[0])),
+		RemoteDatabase: types.StringValue(unquote(engineArgs[1])),
+		RemoteTable:    types.StringValue(unquote(engineArgs[2])),
+		ShardingKey:    types.StringNull(),
+		PolicyName:     types.StringNull(),
+		Settings:       types.MapNull(types.StringType),
+		Comment:        types.StringNull(),
+		QuerySettings:  types.MapNull(types.StringType),
+		Timeouts:       nullTimeouts(),
+	}
+	if len(engineArgs) > 3 {
+		state.ShardingKey = types.StringValue(engineArgs[3])
+	}
+	if len(engineArgs) > 4 {
+		state.PolicyName = types.StringValue(unquote(engineArgs[4]))
+	}
+
+	if _, err := r.readTable(ctx, &state); err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing ClickHouse distributed table",
+			"Could not read ClickHouse distributed table, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+// distributedTableLocalConfig creates the local table the Distributed tables
+// of the tests read from.
+const distributedTableLocalConfig = `
+resource "clickhouse_sql" "local" {
+  create  = "CREATE TABLE default.tf_test_events_local (id UInt64, payload String) ENGINE = MergeTree ORDER BY id"
+  destroy = "DROP TABLE IF EXISTS default.tf_test_events_local"
+}
+`
+
+func TestDistributedTableResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Settings the Distributed engine does not know are rejected
+			// before anything is created.
+			{
+				Config: providerConfig + distributedTableLocalConfig + `
+resource "clickhouse_distributed_table" "test" {
+  database        = "default"
+  name            = "tf_test_events"
+  cluster         = "default"
+  remote_database = "default"
+  remote_table    = "tf_test_events_local"
+
+  settings = {
+    index_granularity = "8192"
+  }
+
+  depends_on = [clickhouse_sql.local]
+}
+`,
+				ExpectError: regexp.MustCompile(`Unknown Distributed Setting`),
+			},
+			// Create and Read testing
+			{
+				Config: providerConfig + distributedTableLocalConfig + `
+resource "clickhouse_distributed_table" "test" {
+  database        = "default"
+  name            = "tf_test_events"
+  cluster         = "default"
+  remote_database = "default"
+  remote_table    = "tf_test_events_local"
+  sharding_key    = "rand()"
+
+  settings = {
+    fsync_after_insert    = "0"
+    bytes_to_delay_insert = "1000000"
+  }
+
+  depends_on = [clickhouse_sql.local]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_distributed_table.test", "settings.bytes_to_delay_insert", "1000000"),
+					resource.TestCheckResourceAttr("clickhouse_distributed_table.test", "columns.#", "2"),
+					resource.TestCheckResourceAttr("clickhouse_distributed_table.test", "columns.0.name", "id"),
+				),
+			},
+			// Update and Read testing. Changing a setting runs MODIFY
+			// SETTING instead of replacing the table.
+			{
+				Config: providerConfig + distributedTableLocalConfig + `
+resource "clickhouse_distributed_table" "test" {
+  database        = "default"
+  name            = "tf_test_events"
+  cluster         = "default"
+  remote_database = "default"
+  remote_table    = "tf_test_events_local"
+  sharding_key    = "rand()"
+
+  settings = {
+    fsync_after_insert    = "0"
+    bytes_to_delay_insert = "2000000"
+  }
+
+  depends_on = [clickhouse_sql.local]
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("clickhouse_distributed_table.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_distributed_table.test", "settings.bytes_to_delay_insert", "2000000"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,371 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &clickhouseFunctionResource{}
+	_ resource.ResourceWithConfigure   = &clickhouseFunctionResource{}
+	_ resource.ResourceWithImportState = &clickhouseFunctionResource{}
+)
+
+// clickhouseFunctionResource is the resource implementation.
+type clickhouseFunctionResource struct {
+	client clickhouseClient
+}
+
+// clickhouseFunctionResourceModel maps the resource schema data.
+type clickhouseFunctionResourceModel struct {
+	Name          types.String   `tfsdk:"name"`
+	Parameters    types.List     `tfsdk:"parameters"`
+	Body          types.String   `tfsdk:"body"`
+	OnCluster     types.String   `tfsdk:"on_cluster"`
+	CreateQuery   types.String   `tfsdk:"create_query"`
+	QuerySettings types.Map      `tfsdk:"query_settings"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *clickhouseFunctionResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "clickhouse_function"
+}
+
+// Schema defines the schema for the resource.
+func (r *clickhouseFunctionResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the user-defined function.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"parameters": schema.ListAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "The parameter names of the lambda, e.g. [\"x\", \"k\", \"b\"].",
+			},
+			"body": schema.StringAttribute{
+				Required:    true,
+				Description: "The lambda body, e.g. k*x + b.",
+			},
+			"on_cluster": schema.StringAttribute{
+				Optional:    true,
+				Description: "The cluster to create the function on. Defaults to the provider cluster.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"create_query": schema.StringAttribute{
+				Computed:    true,
+				Description: "The CREATE FUNCTION statement as stored by the server, used to detect changes made outside of Terraform.",
+			},
+			"query_settings": querySettingsAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+// createQuery renders the statement creating or replacing the function.
+func (m *clickhouseFunctionResourceModel) createQuery(ctx context.Context, orReplace bool, defaultCluster string, diags *diag.Diagnostics) string {
+	parameters := stringsFromList(ctx, m.Parameters, diags)
+
+	query := "CREATE "
+	if orReplace {
+		query += "OR REPLACE "
+	}
+	query += "FUNCTION " + quoteIdentifier(m.Name.ValueString()) + onClusterClause(m.OnCluster, defaultCluster)
+	return query + " AS (" + strings.Join(parameters, ", ") + ") -> " + m.Body.ValueString()
+}
+
+// functionLambdaPattern matches the lambda of a CREATE FUNCTION statement,
+// with or without parentheses around its parameters, across any whitespace.
+var functionLambdaPattern = regexp.MustCompile(`(?s)\bAS\s*(?:\(([^)]*)\)|([A-Za-z_][A-Za-z0-9_]*))\s*->\s*(.*?)\s*;?\s*$`)
+
+// parseFunctionCreateQuery splits a stored CREATE FUNCTION statement into the
+// lambda parameters and body.
+func parseFunctionCreateQuery(query string) ([]string, string, bool) {
+	match := functionLambdaPattern.FindStringSubmatch(query)
+	if match == nil || match[3] == "" {
+		return nil, "", false
+	}
+
+	parameterList := match[1]
+	if match[2] != "" {
+		parameterList = match[2]
+	}
+	var parameters []string
+	for _, parameter := range strings.Split(parameterList, ",") {
+		if parameter = strings.TrimSpace(parameter); parameter != "" {
+			parameters = append(parameters, parameter)
+		}
+	}
+	return parameters, match[3], true
+}
+
+// Create handles the creation of the resource.
+func (r *clickhouseFunctionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withOperation(ctx, "clickhouse_function", "create")
+
+	var plan clickhouseFunctionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, createTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, plan.QuerySettings, &resp.Diagnostics)
+
+	createFunctionQuery := plan.createQuery(ctx, false, r.client.Cluster(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.ExecDDL(ctx, createFunctionQuery); err != nil {
+		addDDLError(&resp.Diagnostics, "Error creating ClickHouse function", "Could not create ClickHouse function", err)
+		return
+	}
+
+	storedQuery, _, err := r.readCreateQuery(ctx, plan.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading ClickHouse function",
+			"Could not read ClickHouse function after creating it, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.CreateQuery = types.StringValue(storedQuery)
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// readCreateQuery fetches the stored definition of a SQL user-defined
+// function. It reports false when the function does not exist.
+func (r *clickhouseFunctionResource) readCreateQuery(ctx context.Context, name string) (string, bool, error) {
+	rows, err := r.client.Query(ctx, "SELECT create_query FROM system.functions WHERE name = ? AND origin = 'SQLUserDefined'", name)
+	if err != nil {
+		return "", false, err
+	}
+	defer rows.Close()
+
+	var createQuery string
+	found := false
+	for rows.Next() {
+		found = true
+		if err := rows.Scan(&createQuery); err != nil {
+			return "", false, err
+		}
+	}
+	return createQuery, found, rows.Err()
+}
+
+// Read handles reading the resource data.
+func (r *clickhouseFunctionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withOperation(ctx, "clickhouse_function", "read")
+
+	var state clickhouseFunctionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, readTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, state.QuerySettings, &resp.Diagnostics)
+
+	createQuery, found, err := r.readCreateQuery(ctx, state.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading ClickHouse function",
+			"Could not read ClickHouse function, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// The server normalizes the lambda, so the configured parameters and body
+	// are only replaced once the stored definition no longer matches the one
+	// recorded at the last apply, and the body is kept as written when it
+	// only differs in whitespace.
+	if createQuery != state.CreateQuery.ValueString() {
+		if parameters, body, ok := parseFunctionCreateQuery(createQuery); ok {
+			state.Parameters = stringList(parameters)
+			if !sameExpression(state.Body.ValueString(), body) {
+				state.Body = types.StringValue(body)
+			}
+		}
+		state.CreateQuery = types.StringValue(createQuery)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update handles updating the resource. SQL user-defined functions cannot be
+// altered, so the function is replaced with CREATE OR REPLACE FUNCTION.
+func (r *clickhouseFunctionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withOperation(ctx, "clickhouse_function", "update")
+
+	var plan clickhouseFunctionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, updateTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, plan.QuerySettings, &resp.Diagnostics)
+
+	updateFunctionQuery := plan.createQuery(ctx, true, r.client.Cluster(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.ExecDDL(ctx, updateFunctionQuery); err != nil {
+		addDDLError(&resp.Diagnostics, "Error updating ClickHouse function", "Could not update ClickHouse function", err)
+		return
+	}
+
+	storedQuery, _, err := r.readCreateQuery(ctx, plan.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading ClickHouse function",
+			"Could not read ClickHouse function after updating it, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.CreateQuery = types.StringValue(storedQuery)
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete handles deleting the resource.
+func (r *clickhouseFunctionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withOperation(ctx, "clickhouse_function", "delete")
+
+	var state clickhouseFunctionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, deleteTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, state.QuerySettings, &resp.Diagnostics)
+
+	deleteFunctionQuery := "DROP FUNCTION IF EXISTS " + quoteIdentifier(state.Name.ValueString()) +
+		onClusterClause(state.OnCluster, r.client.Cluster())
+
+	if err := r.client.ExecDDL(ctx, deleteFunctionQuery); err != nil {
+		addDDLError(&resp.Diagnostics, "Error deleting ClickHouse function", "Could not delete ClickHouse function", err)
+		return
+	}
+}
+
+// Configure configures the resource with the provider data.
+func (r *clickhouseFunctionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(clickhouseClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected clickhouseClient, got something else",
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ImportState imports an existing SQL user-defined function by name.
+func (r *clickhouseFunctionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = withOperation(ctx, "clickhouse_function", "import")
+
+	createQuery, found, err := r.readCreateQuery(ctx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing ClickHouse function",
+			"Could not read ClickHouse function, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if !found {
+		resp.Diagnostics.AddError(
+			"Function does not exist",
+			"The ClickHouse function "+req.ID+" does not exist.",
+		)
+		return
+	}
+
+	parameters, body, ok := parseFunctionCreateQuery(createQuery)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Error importing ClickHouse function",
+			"Could not parse the definition of ClickHouse function "+req.ID+": "+createQuery,
+		)
+		return
+	}
+
+	state := clickhouseFunctionResourceModel{
+		Name:          types.StringValue(req.ID),
+		Parameters:    stringList(parameters),
+		Body:          types.StringValue(body),
+		OnCluster:     types.StringNull(),
+		CreateQuery:   types.StringValue(createQuery),
+		QuerySettings: types.MapNull(types.StringType),
+		Timeouts:      nullTimeouts(),
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestFunctionResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing. The server stores the body as x + 1;
+			// the body as written must be kept.
+			{
+				Config: providerConfig + `
+resource "clickhouse_function" "test" {
+  name       = "tf_test_increment"
+  parameters = ["x"]
+  body       = "x+1"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_function.test", "body", "x+1"),
+					resource.TestMatchResourceAttr("clickhouse_function.test", "create_query", regexp.MustCompile(`x \+ 1`)),
+				),
+			},
+			// Re-plan against the reformatted definition.
+			{
+				Config: providerConfig + `
+resource "clickhouse_function" "test" {
+  name       = "tf_test_increment"
+  parameters = ["x"]
+  body       = "x+1"
+}
+`,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+			// Update with CREATE OR REPLACE FUNCTION and Read testing
+			{
+				Config: providerConfig + `
+resource "clickhouse_function" "test" {
+  name       = "tf_test_increment"
+  parameters = ["x", "step"]
+  body       = "x+step"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_function.test", "parameters.#", "2"),
+					resource.TestCheckResourceAttr("clickhouse_function.test", "body", "x+step"),
+					resource.TestMatchResourceAttr("clickhouse_function.test", "create_query", regexp.MustCompile(`\(x, step\) -> \(?x \+ step\)?`)),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "clickhouse_function" "test" {
+  name       = "tf_test_increment"
+  parameters = ["x", "step"]
+  body       = "x+step"
+}
+`,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+func TestParseFunctionCreateQuery(t *testing.T) {
+	for _, test := range []struct {
+		query      string
+		parameters []string
+		body       string
+		ok         bool
+	}{
+		{
+			"CREATE FUNCTION linear_equation AS (x, k, b) -> ((k * x) + b)",
+			[]string{"x", "k", "b"},
+			"((k * x) + b)",
+			true,
+		},
+		{
+			"CREATE FUNCTION has_tag ON CLUSTER `main` AS (tags, tag) -> has(tags, tag)",
+			[]string{"tags", "tag"},
+			"has(tags, tag)",
+			true,
+		},
+		{
+			"CREATE FUNCTION f AS (a,b)->a+b",
+			[]string{"a", "b"},
+			"a+b",
+			true,
+		},
+		{
+			"CREATE FUNCTION sign_name AS (x)\n    -> multiIf(x > 0, 'positive', x < 0, 'negative', 'zero')\n",
+			[]string{"x"},
+			"multiIf(x > 0, 'positive', x < 0, 'negative', 'zero')",
+			true,
+		},
+		{
+			"CREATE FUNCTION doubled AS (arr) -> arrayMap(x -> (x * 2), arr)",
+			[]string{"arr"},
+			"arrayMap(x -> (x * 2), arr)",
+			true,
+		},
+		{
+			"CREATE FUNCTION increment AS x -> x + 1",
+			[]string{"x"},
+			"x + 1",
+			true,
+		},
+		{
+			"CREATE FUNCTION answer AS () -> 42;",
+			nil,
+			"42",
+			true,
+		},
+		{
+			"CREATE FUNCTION broken",
+			nil,
+			"",
+			false,
+		},
+	} {
+		parameters, body, ok := parseFunctionCreateQuery(test.query)
+		if ok != test.ok || body != test.body || !reflect.DeepEqual(parameters, test.parameters) {
+			t.Errorf("parseFunctionCreateQuery(%q) = %q, %q, %t, want %q, %q, %t",
+				test.query, parameters, body, ok, test.parameters, test.body, test.ok)
+		}
+	}
+}
@@ -0,0 +1,435 @@
+package provider
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &clickhouseNamedCollectionResource{}
+	_ resource.ResourceWithConfigure      = &clickhouseNamedCollectionResource{}
+	_ resource.ResourceWithImportState    = &clickhouseNamedCollectionResource{}
+	_ resource.ResourceWithValidateConfig = &clickhouseNamedCollectionResource{}
+	_ resource.ResourceWithModifyPlan     = &clickhouseNamedCollectionResource{}
+)
+
+// hiddenNamedCollectionValue is what system.named_collections reports in
+// place of values the current user may not see.
+const hiddenNamedCollectionValue = "[HIDDEN]"
+
+// clickhouseNamedCollectionResource is the resource implementation.
+type clickhouseNamedCollectionResource struct {
+	client clickhouseClient
+}
+
+// clickhouseNamedCollectionResourceModel maps the resource schema data.
+type clickhouseNamedCollectionResourceModel struct {
+	Name          types.String                                 `tfsdk:"name"`
+	Keys          map[string]clickhouseNamedCollectionKeyModel `tfsdk:"keys"`
+	QuerySettings types.Map                                    `tfsdk:"query_settings"`
+	Timeouts      timeouts.Value                               `tfsdk:"timeouts"`
+}
+
+// clickhouseNamedCollectionKeyModel maps a single key of the collection.
+type clickhouseNamedCollectionKeyModel struct {
+	Value          types.String `tfsdk:"value"`
+	SensitiveValue types.String `tfsdk:"sensitive_value"`
+	Overridable    types.Bool   `tfsdk:"overridable"`
+}
+
+// effectiveValue returns whichever of value and sensitive_value is set.
+func (m clickhouseNamedCollectionKeyModel) effectiveValue() string {
+	if !m.SensitiveValue.IsNull() {
+		return m.SensitiveValue.ValueString()
+	}
+	return m.Value.ValueString()
+}
+
+// assignment renders the key = value part of a named collection statement.
+func (m clickhouseNamedCollectionKeyModel) assignment(key string) string {
+	assignment := quoteIdentifier(key) + " = " + quoteString(m.effectiveValue())
+	if !m.Overridable.IsNull() {
+		if m.Overridable.ValueBool() {
+			assignment += " OVERRIDABLE"
+		} else {
+			assignment += " NOT OVERRIDABLE"
+		}
+	}
+	return assignment
+}
+
+// sortedKeys returns the keys of the collection in a stable order.
+func (m *clickhouseNamedCollectionResourceModel) sortedKeys() []string {
+	keys := make([]string, 0, len(m.Keys))
+	for key := range m.Keys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Metadata returns the resource type name.
+func (r *clickhouseNamedCollectionResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "clickhouse_named_collection"
+}
+
+// Schema defines the schema for the resource.
+func (r *clickhouseNamedCollectionResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the named collection.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"keys": schema.MapNestedAttribute{
+				Required: true,
+				Description: "Keys of the collection. Set either value or sensitive_value for each key; " +
+					"sensitive_value keeps the value out of plan output.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"value": schema.StringAttribute{
+							Optional:    true,
+							Description: "The value of the key.",
+						},
+						"sensitive_value": schema.StringAttribute{
+							Optional:    true,
+							Sensitive:   true,
+							Description: "The value of the key, for credentials and other secrets.",
+						},
+						"overridable": schema.BoolAttribute{
+							Optional:    true,
+							Description: "Whether queries may override the key. Leave unset to use the server default.",
+						},
+					},
+				},
+			},
+			"query_settings": querySettingsAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+// ValidateConfig ensures every key has exactly one value.
+func (r *clickhouseNamedCollectionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config clickhouseNamedCollectionResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for key, value := range config.Keys {
+		if value.Value.IsUnknown() || value.SensitiveValue.IsUnknown() {
+			continue
+		}
+		if value.Value.IsNull() == value.SensitiveValue.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("keys").AtMapKey(key),
+				"Invalid Named Collection Key",
+				"Exactly one of value or sensitive_value must be set for key "+key+".",
+			)
+		}
+	}
+}
+
+// ModifyPlan rejects the collection when the connected server cannot manage
+// named collections with SQL or does not support overridable keys.
+func (r *clickhouseNamedCollectionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan clickhouseNamedCollectionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.client.Server().requireCapability(path.Root("name"), capNamedCollectionDDL, &resp.Diagnostics)
+	for _, key := range plan.sortedKeys() {
+		if !plan.Keys[key].Overridable.IsNull() {
+			r.client.Server().requireCapability(path.Root("keys").AtMapKey(key).AtName("overridable"), capNamedCollectionOverridable, &resp.Diagnostics)
+		}
+	}
+}
+
+// Create handles the creation of the resource.
+func (r *clickhouseNamedCollectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withOperation(ctx, "clickhouse_named_collection", "create")
+
+	var plan clickhouseNamedCollectionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, createTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, plan.QuerySettings, &resp.Diagnostics)
+
+	var assignments []string
+	for _, key := range plan.sortedKeys() {
+		assignments = append(assignments, plan.Keys[key].assignment(key))
+	}
+
+	createCollectionQuery := "CREATE NAMED COLLECTION " + quoteIdentifier(plan.Name.ValueString()) +
+		" AS " + strings.Join(assignments, ", ")
+
+	if err := r.client.Exec(ctx, createCollectionQuery); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating ClickHouse named collection",
+			"Could not create ClickHouse named collection, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read handles reading the resource data.
+func (r *clickhouseNamedCollectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withOperation(ctx, "clickhouse_named_collection", "read")
+
+	var state clickhouseNamedCollectionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, readTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, state.QuerySettings, &resp.Diagnostics)
+
+	found, err := r.readCollection(ctx, &state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading ClickHouse named collection",
+			"Could not read ClickHouse named collection, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// readCollection refreshes the model from system.named_collections. When the
+// server hides values only the set of keys is compared.
+func (r *clickhouseNamedCollectionResource) readCollection(ctx context.Context, state *clickhouseNamedCollectionResourceModel) (bool, error) {
+	rows, err := r.client.Query(ctx, "SELECT collection FROM system.named_collections WHERE name = ?", state.Name.ValueString())
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	found := false
+	var collection map[string]string
+	for rows.Next() {
+		found = true
+		if err := rows.Scan(&collection); err != nil {
+			return false, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+	if !found {
+		return false, nil
+	}
+
+	keys := make(map[string]clickhouseNamedCollectionKeyModel, len(collection))
+	for key, value := range collection {
+		current, ok := state.Keys[key]
+		switch {
+		case !ok:
+			// Keys added outside of Terraform are treated as secrets since
+			// there is no way to tell what they hold.
+			current = clickhouseNamedCollectionKeyModel{
+				Value:          types.StringNull(),
+				SensitiveValue: types.StringValue(value),
+				Overridable:    types.BoolNull(),
+			}
+		case value == hiddenNamedCollectionValue:
+		case !current.SensitiveValue.IsNull():
+			current.SensitiveValue = types.StringValue(value)
+		default:
+			current.Value = types.StringValue(value)
+		}
+		keys[key] = current
+	}
+
+	state.Keys = keys
+	return true, nil
+}
+
+// Update handles updating the resource. Only keys whose value or
+// overridability changed are set, and removed keys are deleted.
+func (r *clickhouseNamedCollectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withOperation(ctx, "clickhouse_named_collection", "update")
+
+	var plan, state clickhouseNamedCollectionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, updateTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, plan.QuerySettings, &resp.Diagnostics)
+
+	var assignments, deletions []string
+	for _, key := range plan.sortedKeys() {
+		planned := plan.Keys[key]
+		current, ok := state.Keys[key]
+		if ok && planned.effectiveValue() == current.effectiveValue() && planned.Overridable.Equal(current.Overridable) {
+			continue
+		}
+		assignments = append(assignments, planned.assignment(key))
+	}
+	for _, key := range state.sortedKeys() {
+		if _, ok := plan.Keys[key]; !ok {
+			deletions = append(deletions, quoteIdentifier(key))
+		}
+	}
+
+	if len(assignments) > 0 || len(deletions) > 0 {
+		updateCollectionQuery := "ALTER NAMED COLLECTION " + quoteIdentifier(plan.Name.ValueString())
+		if len(assignments) > 0 {
+			updateCollectionQuery += " SET " + strings.Join(assignments, ", ")
+		}
+		if len(deletions) > 0 {
+			updateCollectionQuery += " DELETE " + strings.Join(deletions, ", ")
+		}
+
+		if err := r.client.Exec(ctx, updateCollectionQuery); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating ClickHouse named collection",
+				"Could not update ClickHouse named collection, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete handles deleting the resource.
+func (r *clickhouseNamedCollectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withOperation(ctx, "clickhouse_named_collection", "delete")
+
+	var state clickhouseNamedCollectionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, deleteTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, state.QuerySettings, &resp.Diagnostics)
+
+	deleteCollectionQuery := "DROP NAMED COLLECTION IF EXISTS " + quoteIdentifier(state.Name.ValueString())
+
+	if err := r.client.Exec(ctx, deleteCollectionQuery); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting ClickHouse named collection",
+			"Could not delete ClickHouse named collection, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// Configure configures the resource with the provider data.
+func (r *clickhouseNamedCollectionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(clickhouseClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected clickhouseClient, got something else",
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ImportState imports an existing named collection by name. All keys are
+// imported as sensitive values.
+func (r *clickhouseNamedCollectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = withOperation(ctx, "clickhouse_named_collection", "import")
+
+	state := clickhouseNamedCollectionResourceModel{
+		Name:          types.StringValue(req.ID),
+		QuerySettings: types.MapNull(types.StringType),
+		Timeouts:      nullTimeouts(),
+	}
+
+	found, err := r.readCollection(ctx, &state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing ClickHouse named collection",
+			"Could not read ClickHouse named collection, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if !found {
+		resp.Diagnostics.AddError(
+			"Named collection does not exist",
+			"The ClickHouse named collection "+req.ID+" does not exist.",
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
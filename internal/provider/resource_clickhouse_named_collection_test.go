@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestNamedCollectionResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "clickhouse_named_collection" "test" {
+  name = "tf_test_collection"
+
+  keys = {
+    url = {
+      value = "https://bucket.s3.amazonaws.com/data/"
+    }
+    region = {
+      value       = "eu-west-1"
+      overridable = true
+    }
+    secret_access_key = {
+      sensitive_value = "first-secret"
+    }
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_named_collection.test", "keys.%", "3"),
+					resource.TestCheckResourceAttr("clickhouse_named_collection.test", "keys.secret_access_key.sensitive_value", "first-secret"),
+				),
+			},
+			// Update and Read testing. The sensitive key changes with ALTER
+			// ... SET and the region key is removed with ALTER ... DELETE.
+			{
+				Config: providerConfig + `
+resource "clickhouse_named_collection" "test" {
+  name = "tf_test_collection"
+
+  keys = {
+    url = {
+      value = "https://bucket.s3.amazonaws.com/data/"
+    }
+    secret_access_key = {
+      sensitive_value = "second-secret"
+    }
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_named_collection.test", "keys.%", "2"),
+					resource.TestCheckNoResourceAttr("clickhouse_named_collection.test", "keys.region.value"),
+					resource.TestCheckResourceAttr("clickhouse_named_collection.test", "keys.secret_access_key.sensitive_value", "second-secret"),
+				),
+			},
+		},
+	})
+}
+
+func TestReadHiddenNamedCollection(t *testing.T) {
+	client := &fakeClient{results: map[string]*fakeRows{
+		"SELECT collection FROM system.named_collections WHERE name = ?": {
+			columns: []fakeColumn{{"collection", "Map(String, String)", reflect.TypeOf(map[string]string{})}},
+			values: [][]any{{map[string]string{
+				"url":               "https://bucket.s3.amazonaws.com/data/",
+				"secret_access_key": hiddenNamedCollectionValue,
+			}}},
+		},
+	}}
+	r := &clickhouseNamedCollectionResource{client: client}
+
+	secret := clickhouseNamedCollectionKeyModel{
+		Value:          types.StringNull(),
+		SensitiveValue: types.StringValue("second-secret"),
+		Overridable:    types.BoolNull(),
+	}
+	state := clickhouseNamedCollectionResourceModel{
+		Name: types.StringValue("tf_test_collection"),
+		Keys: map[string]clickhouseNamedCollectionKeyModel{
+			"url": {
+				Value:          types.StringValue("https://old.example.com/"),
+				SensitiveValue: types.StringNull(),
+				Overridable:    types.BoolNull(),
+			},
+			"secret_access_key": secret,
+			"region": {
+				Value:          types.StringValue("eu-west-1"),
+				SensitiveValue: types.StringNull(),
+				Overridable:    types.BoolNull(),
+			},
+		},
+	}
+
+	found, err := r.readCollection(context.Background(), &state)
+	if err != nil || !found {
+		t.Fatalf("readCollection() = %t, %v", found, err)
+	}
+	if len(state.Keys) != 2 {
+		t.Errorf("keys = %v, want the removed region key gone", state.Keys)
+	}
+	if state.Keys["secret_access_key"] != secret {
+		t.Errorf("hidden key read as %+v, want the value in state kept", state.Keys["secret_access_key"])
+	}
+	if got := state.Keys["url"].Value.ValueString(); got != "https://bucket.s3.amazonaws.com/data/" {
+		t.Errorf("visible key read as %q", got)
+	}
+}
@@ -0,0 +1,598 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &clickhouseQuotaResource{}
+	_ resource.ResourceWithConfigure      = &clickhouseQuotaResource{}
+	_ resource.ResourceWithImportState    = &clickhouseQuotaResource{}
+	_ resource.ResourceWithValidateConfig = &clickhouseQuotaResource{}
+)
+
+// quotaKeys lists the accepted values of keyed_by.
+var quotaKeys = []string{
+	"user_name",
+	"ip_address",
+	"forwarded_ip_address",
+	"client_key",
+	"client_key,user_name",
+	"client_key,ip_address",
+}
+
+// clickhouseQuotaResource is the resource implementation.
+type clickhouseQuotaResource struct {
+	client clickhouseClient
+}
+
+// clickhouseQuotaResourceModel maps the resource schema data.
+type clickhouseQuotaResourceModel struct {
+	Name          types.String                   `tfsdk:"name"`
+	KeyedBy       types.String                   `tfsdk:"keyed_by"`
+	To            types.Set                      `tfsdk:"to"`
+	Intervals     []clickhouseQuotaIntervalModel `tfsdk:"interval"`
+	QuerySettings types.Map                      `tfsdk:"query_settings"`
+	Timeouts      timeouts.Value                 `tfsdk:"timeouts"`
+}
+
+// clickhouseQuotaIntervalModel maps a single interval block.
+type clickhouseQuotaIntervalModel struct {
+	Duration      types.Int64   `tfsdk:"duration"`
+	Randomized    types.Bool    `tfsdk:"randomized"`
+	TrackingOnly  types.Bool    `tfsdk:"tracking_only"`
+	Queries       types.Int64   `tfsdk:"queries"`
+	QuerySelects  types.Int64   `tfsdk:"query_selects"`
+	QueryInserts  types.Int64   `tfsdk:"query_inserts"`
+	Errors        types.Int64   `tfsdk:"errors"`
+	ResultRows    types.Int64   `tfsdk:"result_rows"`
+	ResultBytes   types.Int64   `tfsdk:"result_bytes"`
+	ReadRows      types.Int64   `tfsdk:"read_rows"`
+	ReadBytes     types.Int64   `tfsdk:"read_bytes"`
+	WrittenBytes  types.Int64   `tfsdk:"written_bytes"`
+	ExecutionTime types.Float64 `tfsdk:"execution_time"`
+}
+
+// quotaLimit pairs the ClickHouse name of an integer limit with its value.
+type quotaLimit struct {
+	name  string
+	value *types.Int64
+}
+
+// countLimits returns the integer limits of the interval.
+func (m *clickhouseQuotaIntervalModel) countLimits() []quotaLimit {
+	return []quotaLimit{
+		{"queries", &m.Queries},
+		{"query_selects", &m.QuerySelects},
+		{"query_inserts", &m.QueryInserts},
+		{"errors", &m.Errors},
+		{"result_rows", &m.ResultRows},
+		{"result_bytes", &m.ResultBytes},
+		{"read_rows", &m.ReadRows},
+		{"read_bytes", &m.ReadBytes},
+		{"written_bytes", &m.WrittenBytes},
+	}
+}
+
+// hasLimits reports whether any limit is set on the interval.
+func (m *clickhouseQuotaIntervalModel) hasLimits() bool {
+	for _, limit := range m.countLimits() {
+		if !limit.value.IsNull() {
+			return true
+		}
+	}
+	return !m.ExecutionTime.IsNull()
+}
+
+// clause renders the FOR INTERVAL part of a quota statement.
+func (m *clickhouseQuotaIntervalModel) clause() string {
+	clause := "FOR "
+	if m.Randomized.ValueBool() {
+		clause += "RANDOMIZED "
+	}
+	clause += fmt.Sprintf("INTERVAL %d second", m.Duration.ValueInt64())
+
+	if m.TrackingOnly.ValueBool() || !m.hasLimits() {
+		return clause + " TRACKING ONLY"
+	}
+
+	var limits []string
+	for _, limit := range m.countLimits() {
+		if !limit.value.IsNull() {
+			limits = append(limits, fmt.Sprintf("%s = %d", limit.name, limit.value.ValueInt64()))
+		}
+	}
+	if !m.ExecutionTime.IsNull() {
+		limits = append(limits, "execution_time = "+strconv.FormatFloat(m.ExecutionTime.ValueFloat64(), 'f', -1, 64))
+	}
+	return clause + " MAX " + strings.Join(limits, ", ")
+}
+
+// Metadata returns the resource type name.
+func (r *clickhouseQuotaResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "clickhouse_quota"
+}
+
+// Schema defines the schema for the resource.
+func (r *clickhouseQuotaResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	limitAttribute := func(description string) schema.Int64Attribute {
+		return schema.Int64Attribute{
+			Optional:    true,
+			Description: description,
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the quota. Changing it renames the quota in place.",
+			},
+			"keyed_by": schema.StringAttribute{
+				Optional:    true,
+				Description: "The key the quota is tracked by: " + strings.Join(quotaKeys, ", ") + ". Leave unset for a quota shared by everyone it applies to.",
+			},
+			"to": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Users and roles the quota is assigned to.",
+			},
+			"query_settings": querySettingsAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+			"interval": schema.ListNestedBlock{
+				Description: "Limits for one interval. Intervals removed from the configuration are dropped with NO LIMITS.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"duration": schema.Int64Attribute{
+							Required:    true,
+							Description: "The length of the interval in seconds.",
+						},
+						"randomized": schema.BoolAttribute{
+							Optional:    true,
+							Description: "Whether the interval start is randomized.",
+						},
+						"tracking_only": schema.BoolAttribute{
+							Optional:    true,
+							Description: "Only track consumption for the interval without enforcing limits.",
+						},
+						"queries":       limitAttribute("The maximum number of queries."),
+						"query_selects": limitAttribute("The maximum number of SELECT queries."),
+						"query_inserts": limitAttribute("The maximum number of INSERT queries."),
+						"errors":        limitAttribute("The maximum number of queries that threw an exception."),
+						"result_rows":   limitAttribute("The maximum number of rows returned as results."),
+						"result_bytes":  limitAttribute("The maximum number of bytes returned as results."),
+						"read_rows":     limitAttribute("The maximum number of source rows read from tables."),
+						"read_bytes":    limitAttribute("The maximum number of bytes read from tables."),
+						"written_bytes": limitAttribute("The maximum number of bytes written."),
+						"execution_time": schema.Float64Attribute{
+							Optional:    true,
+							Description: "The maximum total query execution time in seconds.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks the key and intervals before they reach the server.
+func (r *clickhouseQuotaResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config clickhouseQuotaResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.KeyedBy.IsNull() && !config.KeyedBy.IsUnknown() {
+		valid := false
+		for _, key := range quotaKeys {
+			if config.KeyedBy.ValueString() == key {
+				valid = true
+			}
+		}
+		if !valid {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("keyed_by"),
+				"Invalid Quota Key",
+				"The keyed_by value must be one of "+strings.Join(quotaKeys, ", ")+", got: "+config.KeyedBy.ValueString(),
+			)
+		}
+	}
+
+	durations := map[int64]bool{}
+	for i, interval := range config.Intervals {
+		intervalPath := path.Root("interval").AtListIndex(i)
+		if interval.Duration.IsUnknown() {
+			continue
+		}
+		if interval.Duration.ValueInt64() <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				intervalPath.AtName("duration"),
+				"Invalid Quota Interval",
+				"The interval duration must be a positive number of seconds.",
+			)
+		}
+		if durations[interval.Duration.ValueInt64()] {
+			resp.Diagnostics.AddAttributeError(
+				intervalPath.AtName("duration"),
+				"Duplicate Quota Interval",
+				fmt.Sprintf("More than one interval has a duration of %d seconds.", interval.Duration.ValueInt64()),
+			)
+		}
+		durations[interval.Duration.ValueInt64()] = true
+
+		if interval.TrackingOnly.ValueBool() && interval.hasLimits() {
+			resp.Diagnostics.AddAttributeError(
+				intervalPath.AtName("tracking_only"),
+				"Conflicting Quota Interval Limits",
+				"Limits cannot be set on an interval with tracking_only enabled.",
+			)
+		}
+	}
+}
+
+// Create handles the creation of the resource.
+func (r *clickhouseQuotaResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withOperation(ctx, "clickhouse_quota", "create")
+
+	var plan clickhouseQuotaResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, createTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, plan.QuerySettings, &resp.Diagnostics)
+
+	createQuotaQuery := "CREATE QUOTA " + quoteIdentifier(plan.Name.ValueString())
+	if !plan.KeyedBy.IsNull() {
+		createQuotaQuery += " KEYED BY " + plan.KeyedBy.ValueString()
+	}
+	var intervals []string
+	for _, interval := range plan.Intervals {
+		intervals = append(intervals, interval.clause())
+	}
+	if len(intervals) > 0 {
+		createQuotaQuery += " " + strings.Join(intervals, ", ")
+	}
+	if to := stringsFromSet(ctx, plan.To, &resp.Diagnostics); len(to) > 0 {
+		createQuotaQuery += toClause(to)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.Exec(ctx, createQuotaQuery); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating ClickHouse quota",
+			"Could not create ClickHouse quota, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read handles reading the resource data.
+func (r *clickhouseQuotaResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withOperation(ctx, "clickhouse_quota", "read")
+
+	var state clickhouseQuotaResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, readTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, state.QuerySettings, &resp.Diagnostics)
+
+	found, err := r.readQuota(ctx, &state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading ClickHouse quota",
+			"Could not read ClickHouse quota, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// readQuota refreshes the model from system.quotas and system.quota_limits.
+// It reports false when the quota is gone.
+func (r *clickhouseQuotaResource) readQuota(ctx context.Context, state *clickhouseQuotaResourceModel) (bool, error) {
+	name := state.Name.ValueString()
+
+	rows, err := r.client.Query(ctx, "SELECT keys, apply_to_list FROM system.quotas WHERE name = ?", name)
+	if err != nil {
+		return false, err
+	}
+	found := false
+	var keys, applyTo []string
+	for rows.Next() {
+		found = true
+		if err := rows.Scan(&keys, &applyTo); err != nil {
+			rows.Close()
+			return false, err
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+	if !found {
+		return false, nil
+	}
+
+	rows, err = r.client.Query(ctx, `
+		SELECT duration, is_randomized_interval,
+			max_queries, max_query_selects, max_query_inserts, max_errors,
+			max_result_rows, max_result_bytes, max_read_rows, max_read_bytes,
+			max_written_bytes, max_execution_time
+		FROM system.quota_limits
+		WHERE quota_name = ?
+		ORDER BY duration`, name)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	current := map[int64]clickhouseQuotaIntervalModel{}
+	var order []int64
+	for rows.Next() {
+		var duration uint32
+		var randomized uint8
+		var executionTime *float64
+		counts := make([]*uint64, 9)
+		dest := []any{&duration, &randomized}
+		for i := range counts {
+			dest = append(dest, &counts[i])
+		}
+		dest = append(dest, &executionTime)
+		if err := rows.Scan(dest...); err != nil {
+			return false, err
+		}
+
+		interval := clickhouseQuotaIntervalModel{
+			Duration:      types.Int64Value(int64(duration)),
+			Randomized:    types.BoolNull(),
+			TrackingOnly:  types.BoolNull(),
+			ExecutionTime: types.Float64Null(),
+		}
+		if randomized != 0 {
+			interval.Randomized = types.BoolValue(true)
+		}
+		limited := false
+		for i, limit := range interval.countLimits() {
+			*limit.value = types.Int64Null()
+			if counts[i] != nil {
+				*limit.value = types.Int64Value(int64(*counts[i]))
+				limited = true
+			}
+		}
+		if executionTime != nil {
+			interval.ExecutionTime = types.Float64Value(*executionTime)
+			limited = true
+		}
+		if !limited {
+			interval.TrackingOnly = types.BoolValue(true)
+		}
+
+		current[interval.Duration.ValueInt64()] = interval
+		order = append(order, interval.Duration.ValueInt64())
+	}
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+
+	// Keep the configured order of the intervals and the way booleans were
+	// left unset, then append intervals that only exist on the server.
+	var intervals []clickhouseQuotaIntervalModel
+	seen := map[int64]bool{}
+	for _, configured := range state.Intervals {
+		interval, ok := current[configured.Duration.ValueInt64()]
+		if !ok {
+			continue
+		}
+		if configured.Randomized.ValueBool() == interval.Randomized.ValueBool() {
+			interval.Randomized = configured.Randomized
+		}
+		configuredTracking := configured.TrackingOnly.ValueBool() || !configured.hasLimits()
+		if configuredTracking == interval.TrackingOnly.ValueBool() {
+			interval.TrackingOnly = configured.TrackingOnly
+		}
+		intervals = append(intervals, interval)
+		seen[configured.Duration.ValueInt64()] = true
+	}
+	for _, duration := range order {
+		if !seen[duration] {
+			intervals = append(intervals, current[duration])
+		}
+	}
+
+	state.KeyedBy = types.StringNull()
+	if len(keys) > 0 {
+		state.KeyedBy = types.StringValue(strings.Join(keys, ","))
+	}
+	state.To = stringSetValue(state.To, applyTo)
+	state.Intervals = intervals
+	return true, nil
+}
+
+// Update handles updating the resource.
+func (r *clickhouseQuotaResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withOperation(ctx, "clickhouse_quota", "update")
+
+	var plan, state clickhouseQuotaResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, updateTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, plan.QuerySettings, &resp.Diagnostics)
+
+	updateQuotaQuery := "ALTER QUOTA " + quoteIdentifier(state.Name.ValueString())
+	if plan.Name.ValueString() != state.Name.ValueString() {
+		updateQuotaQuery += " RENAME TO " + quoteIdentifier(plan.Name.ValueString())
+	}
+	if plan.KeyedBy.IsNull() {
+		updateQuotaQuery += " NOT KEYED"
+	} else {
+		updateQuotaQuery += " KEYED BY " + plan.KeyedBy.ValueString()
+	}
+
+	// ALTER QUOTA only touches the intervals it mentions, so intervals that
+	// are no longer configured have to be dropped explicitly.
+	var intervals []string
+	planned := map[int64]bool{}
+	for _, interval := range plan.Intervals {
+		intervals = append(intervals, interval.clause())
+		planned[interval.Duration.ValueInt64()] = true
+	}
+	for _, interval := range state.Intervals {
+		if !planned[interval.Duration.ValueInt64()] {
+			intervals = append(intervals, fmt.Sprintf("FOR INTERVAL %d second NO LIMITS", interval.Duration.ValueInt64()))
+		}
+	}
+	if len(intervals) > 0 {
+		updateQuotaQuery += " " + strings.Join(intervals, ", ")
+	}
+	updateQuotaQuery += toClause(stringsFromSet(ctx, plan.To, &resp.Diagnostics))
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.Exec(ctx, updateQuotaQuery); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating ClickHouse quota",
+			"Could not update ClickHouse quota, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete handles deleting the resource.
+func (r *clickhouseQuotaResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withOperation(ctx, "clickhouse_quota", "delete")
+
+	var state clickhouseQuotaResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, deleteTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, state.QuerySettings, &resp.Diagnostics)
+
+	deleteQuotaQuery := "DROP QUOTA IF EXISTS " + quoteIdentifier(state.Name.ValueString())
+
+	if err := r.client.Exec(ctx, deleteQuotaQuery); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting ClickHouse quota",
+			"Could not delete ClickHouse quota, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// Configure configures the resource with the provider data.
+func (r *clickhouseQuotaResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(clickhouseClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected clickhouseClient, got something else",
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ImportState imports an existing quota by name.
+func (r *clickhouseQuotaResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = withOperation(ctx, "clickhouse_quota", "import")
+
+	state := clickhouseQuotaResourceModel{
+		Name:          types.StringValue(req.ID),
+		To:            types.SetNull(types.StringType),
+		QuerySettings: types.MapNull(types.StringType),
+		Timeouts:      nullTimeouts(),
+	}
+
+	found, err := r.readQuota(ctx, &state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing ClickHouse quota",
+			"Could not read ClickHouse quota, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if !found {
+		resp.Diagnostics.AddError(
+			"Quota does not exist",
+			"The ClickHouse quota "+req.ID+" does not exist.",
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestQuotaResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "clickhouse_quota" "test" {
+  name     = "tf_test_quota"
+  keyed_by = "user_name"
+
+  interval {
+    duration       = 3600
+    queries        = 1000
+    errors         = 100
+    execution_time = 1.5
+  }
+
+  interval {
+    duration      = 86400
+    tracking_only = true
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_quota.test", "keyed_by", "user_name"),
+					resource.TestCheckResourceAttr("clickhouse_quota.test", "interval.#", "2"),
+					resource.TestCheckResourceAttr("clickhouse_quota.test", "interval.0.queries", "1000"),
+					resource.TestCheckResourceAttr("clickhouse_quota.test", "interval.0.execution_time", "1.5"),
+					resource.TestCheckResourceAttr("clickhouse_quota.test", "interval.1.tracking_only", "true"),
+				),
+			},
+			// Update and Read testing. The removed interval is dropped with
+			// NO LIMITS and must not come back on refresh.
+			{
+				Config: providerConfig + `
+resource "clickhouse_quota" "test" {
+  name     = "tf_test_quota"
+  keyed_by = "user_name"
+
+  interval {
+    duration = 3600
+    queries  = 2000
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_quota.test", "interval.#", "1"),
+					resource.TestCheckResourceAttr("clickhouse_quota.test", "interval.0.queries", "2000"),
+					resource.TestCheckNoResourceAttr("clickhouse_quota.test", "interval.0.errors"),
+					resource.TestCheckNoResourceAttr("clickhouse_quota.test", "interval.0.execution_time"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "clickhouse_quota.test",
+				ImportState:                          true,
+				ImportStateId:                        "tf_test_quota",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+			},
+		},
+	})
+}
@@ -0,0 +1,463 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &clickhouseRowPolicyResource{}
+	_ resource.ResourceWithConfigure      = &clickhouseRowPolicyResource{}
+	_ resource.ResourceWithImportState    = &clickhouseRowPolicyResource{}
+	_ resource.ResourceWithValidateConfig = &clickhouseRowPolicyResource{}
+)
+
+// clickhouseRowPolicyResource is the resource implementation.
+type clickhouseRowPolicyResource struct {
+	client clickhouseClient
+}
+
+// clickhouseRowPolicyResourceModel maps the resource schema data.
+type clickhouseRowPolicyResourceModel struct {
+	Name          types.String   `tfsdk:"name"`
+	Tables        types.Set      `tfsdk:"tables"`
+	Using         types.String   `tfsdk:"using"`
+	As            types.String   `tfsdk:"as"`
+	To            types.Set      `tfsdk:"to"`
+	QuerySettings types.Map      `tfsdk:"query_settings"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *clickhouseRowPolicyResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "clickhouse_row_policy"
+}
+
+// Schema defines the schema for the resource.
+func (r *clickhouseRowPolicyResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The short name of the row policy. Changing it renames the policy in place.",
+			},
+			"tables": schema.SetAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "Tables the policy applies to, written as database.table. Use database.* for every table of a database.",
+			},
+			"using": schema.StringAttribute{
+				Required:    true,
+				Description: "The filter expression rows must satisfy, e.g. tenant_id = currentUser().",
+			},
+			"as": schema.StringAttribute{
+				Optional:    true,
+				Description: "How the policy combines with other policies on the same table: permissive (default) or restrictive.",
+			},
+			"to": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Users and roles the policy applies to.",
+			},
+			"query_settings": querySettingsAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+// ValidateConfig checks the table references and policy kind.
+func (r *clickhouseRowPolicyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config clickhouseRowPolicyResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.As.IsNull() && !config.As.IsUnknown() {
+		switch strings.ToLower(config.As.ValueString()) {
+		case "permissive", "restrictive":
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("as"),
+				"Invalid Row Policy Kind",
+				"The as value must be permissive or restrictive, got: "+config.As.ValueString(),
+			)
+		}
+	}
+
+	if config.Tables.IsUnknown() {
+		return
+	}
+	for _, table := range stringsFromSet(ctx, config.Tables, &resp.Diagnostics) {
+		database, name, found := strings.Cut(table, ".")
+		if !found || database == "" || name == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("tables"),
+				"Invalid Row Policy Table",
+				"Tables must be written as database.table, got: "+table,
+			)
+		}
+	}
+}
+
+// policyClause renders the part of a row policy statement that follows the
+// table list.
+func (m *clickhouseRowPolicyResourceModel) policyClause() string {
+	clause := ""
+	if !m.As.IsNull() {
+		clause += " AS " + strings.ToLower(m.As.ValueString())
+	}
+	return clause + " FOR SELECT USING " + m.Using.ValueString()
+}
+
+// onClause renders the table list of a row policy statement.
+func onClause(tables []string) string {
+	quoted := make([]string, 0, len(tables))
+	for _, table := range tables {
+		quoted = append(quoted, quoteTableName(table))
+	}
+	return " ON " + strings.Join(quoted, ", ")
+}
+
+// Create handles the creation of the resource.
+func (r *clickhouseRowPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withOperation(ctx, "clickhouse_row_policy", "create")
+
+	var plan clickhouseRowPolicyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, createTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, plan.QuerySettings, &resp.Diagnostics)
+
+	tables := stringsFromSet(ctx, plan.Tables, &resp.Diagnostics)
+	to := stringsFromSet(ctx, plan.To, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createPolicyQuery := "CREATE ROW POLICY " + quoteIdentifier(plan.Name.ValueString()) + onClause(tables) + plan.policyClause()
+	if len(to) > 0 {
+		createPolicyQuery += toClause(to)
+	}
+
+	if err := r.client.Exec(ctx, createPolicyQuery); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating ClickHouse row policy",
+			"Could not create ClickHouse row policy, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read handles reading the resource data.
+func (r *clickhouseRowPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withOperation(ctx, "clickhouse_row_policy", "read")
+
+	var state clickhouseRowPolicyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, readTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, state.QuerySettings, &resp.Diagnostics)
+
+	found, err := r.readPolicy(ctx, &state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading ClickHouse row policy",
+			"Could not read ClickHouse row policy, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// readPolicy refreshes the model from system.row_policies. A policy on
+// several tables is stored as one row per table sharing the short name, and
+// unrelated policies may share that name on other tables, so only the rows of
+// the tables in state are read.
+func (r *clickhouseRowPolicyResource) readPolicy(ctx context.Context, state *clickhouseRowPolicyResourceModel) (bool, error) {
+	managed := map[string]bool{}
+	for _, element := range state.Tables.Elements() {
+		if table, ok := element.(types.String); ok {
+			managed[table.ValueString()] = true
+		}
+	}
+
+	rows, err := r.client.Query(ctx, `
+		SELECT database, table, select_filter, is_restrictive, apply_to_list
+		FROM system.row_policies
+		WHERE short_name = ?
+		ORDER BY database, table`, state.Name.ValueString())
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	var tables, applyTo []string
+	var filter *string
+	var restrictive uint8
+	for rows.Next() {
+		var database, table string
+		var rowFilter *string
+		var rowRestrictive uint8
+		var rowApplyTo []string
+		if err := rows.Scan(&database, &table, &rowFilter, &rowRestrictive, &rowApplyTo); err != nil {
+			return false, err
+		}
+		if table == "" {
+			table = "*"
+		}
+		if !managed[database+"."+table] {
+			continue
+		}
+		tables = append(tables, database+"."+table)
+		filter, restrictive, applyTo = rowFilter, rowRestrictive, rowApplyTo
+	}
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+	if len(tables) == 0 {
+		return false, nil
+	}
+
+	state.Tables = stringSetValue(state.Tables, tables)
+	if filter != nil && !sameExpression(state.Using.ValueString(), *filter) {
+		state.Using = types.StringValue(*filter)
+	}
+	kind := "permissive"
+	if restrictive != 0 {
+		kind = "restrictive"
+	}
+	if !strings.EqualFold(state.As.ValueString(), kind) && (!state.As.IsNull() || restrictive != 0) {
+		state.As = types.StringValue(kind)
+	}
+	state.To = stringSetValue(state.To, applyTo)
+	return true, nil
+}
+
+// Update handles updating the resource. Tables removed from the policy are
+// dropped, kept tables are altered and new tables get a new policy.
+func (r *clickhouseRowPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withOperation(ctx, "clickhouse_row_policy", "update")
+
+	var plan, state clickhouseRowPolicyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, updateTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, plan.QuerySettings, &resp.Diagnostics)
+
+	planTables := stringsFromSet(ctx, plan.Tables, &resp.Diagnostics)
+	stateTables := stringsFromSet(ctx, state.Tables, &resp.Diagnostics)
+	to := stringsFromSet(ctx, plan.To, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned := map[string]bool{}
+	for _, table := range planTables {
+		planned[table] = true
+	}
+	existing := map[string]bool{}
+	var removed, kept, added []string
+	for _, table := range stateTables {
+		existing[table] = true
+		if planned[table] {
+			kept = append(kept, table)
+		} else {
+			removed = append(removed, table)
+		}
+	}
+	for _, table := range planTables {
+		if !existing[table] {
+			added = append(added, table)
+		}
+	}
+
+	oldName := quoteIdentifier(state.Name.ValueString())
+	newName := quoteIdentifier(plan.Name.ValueString())
+
+	var queries []string
+	if len(removed) > 0 {
+		queries = append(queries, "DROP ROW POLICY IF EXISTS "+oldName+onClause(removed))
+	}
+	if len(kept) > 0 {
+		alterPolicyQuery := "ALTER ROW POLICY " + oldName + onClause(kept)
+		if oldName != newName {
+			alterPolicyQuery += " RENAME TO " + newName
+		}
+		queries = append(queries, alterPolicyQuery+plan.policyClause()+toClause(to))
+	}
+	if len(added) > 0 {
+		createPolicyQuery := "CREATE ROW POLICY " + newName + onClause(added) + plan.policyClause()
+		if len(to) > 0 {
+			createPolicyQuery += toClause(to)
+		}
+		queries = append(queries, createPolicyQuery)
+	}
+
+	for _, query := range queries {
+		if err := r.client.Exec(ctx, query); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating ClickHouse row policy",
+				"Could not update ClickHouse row policy, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete handles deleting the resource.
+func (r *clickhouseRowPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withOperation(ctx, "clickhouse_row_policy", "delete")
+
+	var state clickhouseRowPolicyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, deleteTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, state.QuerySettings, &resp.Diagnostics)
+
+	tables := stringsFromSet(ctx, state.Tables, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deletePolicyQuery := "DROP ROW POLICY IF EXISTS " + quoteIdentifier(state.Name.ValueString()) + onClause(tables)
+
+	if err := r.client.Exec(ctx, deletePolicyQuery); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting ClickHouse row policy",
+			"Could not delete ClickHouse row policy, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// Configure configures the resource with the provider data.
+func (r *clickhouseRowPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(clickhouseClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected clickhouseClient, got something else",
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ImportState imports an existing row policy by its short name and tables,
+// written as name ON database.table[,database.table...] like the server
+// names policies.
+func (r *clickhouseRowPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = withOperation(ctx, "clickhouse_row_policy", "import")
+
+	name, tableList, found := strings.Cut(req.ID, " ON ")
+	if !found || name == "" || tableList == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Row policies are imported as name ON database.table[,database.table...], got: "+req.ID,
+		)
+		return
+	}
+	var tables []string
+	for _, table := range strings.Split(tableList, ",") {
+		tables = append(tables, strings.TrimSpace(table))
+	}
+
+	state := clickhouseRowPolicyResourceModel{
+		Name:          types.StringValue(name),
+		Tables:        stringSetValue(types.SetNull(types.StringType), tables),
+		Using:         types.StringNull(),
+		As:            types.StringNull(),
+		To:            types.SetNull(types.StringType),
+		QuerySettings: types.MapNull(types.StringType),
+		Timeouts:      nullTimeouts(),
+	}
+
+	found, err := r.readPolicy(ctx, &state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing ClickHouse row policy",
+			"Could not read ClickHouse row policy, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if !found {
+		resp.Diagnostics.AddError(
+			"Row policy does not exist",
+			"The ClickHouse row policy "+req.ID+" does not exist.",
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
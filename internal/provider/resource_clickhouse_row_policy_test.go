@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestRowPolicyResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "clickhouse_row_policy" "test" {
+  name   = "tf_test_tenant"
+  tables = ["default.events", "default.sessions"]
+  using  = "tenant_id = currentUser()"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_row_policy.test", "tables.#", "2"),
+					resource.TestCheckResourceAttr("clickhouse_row_policy.test", "using", "tenant_id = currentUser()"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "clickhouse_row_policy.test",
+				ImportState:                          true,
+				ImportStateId:                        "tf_test_tenant ON default.events,default.sessions",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+			},
+			// Rename, table change and Read testing
+			{
+				Config: providerConfig + `
+resource "clickhouse_row_policy" "test" {
+  name   = "tf_test_tenant_renamed"
+  tables = ["default.events", "default.pageviews"]
+  using  = "tenant_id = currentUser()"
+  as     = "restrictive"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_row_policy.test", "name", "tf_test_tenant_renamed"),
+					resource.TestCheckResourceAttr("clickhouse_row_policy.test", "tables.#", "2"),
+					resource.TestCheckResourceAttr("clickhouse_row_policy.test", "as", "restrictive"),
+				),
+			},
+		},
+	})
+}
+
+func TestReadPolicyTables(t *testing.T) {
+	filter := "tenant_id = currentUser()"
+	query := `
+		SELECT database, table, select_filter, is_restrictive, apply_to_list
+		FROM system.row_policies
+		WHERE short_name = ?
+		ORDER BY database, table`
+	client := &fakeClient{results: map[string]*fakeRows{
+		query: {
+			columns: []fakeColumn{
+				{"database", "String", stringType},
+				{"table", "String", stringType},
+				{"select_filter", "Nullable(String)", reflect.TypeOf(&filter)},
+				{"is_restrictive", "UInt8", reflect.TypeOf(uint8(0))},
+				{"apply_to_list", "Array(String)", reflect.TypeOf([]string{})},
+			},
+			values: [][]any{
+				{"default", "events", &filter, uint8(0), []string{}},
+				{"other", "", &filter, uint8(1), []string{"alice"}},
+			},
+		},
+	}}
+	r := &clickhouseRowPolicyResource{client: client}
+
+	state := clickhouseRowPolicyResourceModel{
+		Name:   types.StringValue("tenant"),
+		Tables: stringSetValue(types.SetNull(types.StringType), []string{"default.events", "default.sessions"}),
+		Using:  types.StringValue(filter),
+		As:     types.StringNull(),
+		To:     types.SetNull(types.StringType),
+	}
+	found, err := r.readPolicy(context.Background(), &state)
+	if err != nil || !found {
+		t.Fatalf("readPolicy() = %t, %v", found, err)
+	}
+	want := stringSetValue(state.Tables, []string{"default.events"})
+	if !state.Tables.Equal(want) || !state.As.IsNull() || !state.To.IsNull() {
+		t.Errorf("readPolicy() = tables %s, as %s, to %s, want only the policy on the tables in state", state.Tables, state.As, state.To)
+	}
+
+	state.Tables = stringSetValue(state.Tables, []string{"default.sessions"})
+	if found, err := r.readPolicy(context.Background(), &state); err != nil || found {
+		t.Errorf("readPolicy() = %t, %v, want a policy on other tables not found", found, err)
+	}
+}
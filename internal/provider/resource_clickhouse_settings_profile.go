@@ -0,0 +1,448 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &clickhouseSettingsProfileResource{}
+	_ resource.ResourceWithConfigure      = &clickhouseSettingsProfileResource{}
+	_ resource.ResourceWithImportState    = &clickhouseSettingsProfileResource{}
+	_ resource.ResourceWithValidateConfig = &clickhouseSettingsProfileResource{}
+)
+
+// settingWritabilities lists the constraint keywords accepted for a setting.
+var settingWritabilities = []string{"WRITABLE", "CONST", "CHANGEABLE_IN_READONLY"}
+
+// clickhouseSettingsProfileResource is the resource implementation.
+type clickhouseSettingsProfileResource struct {
+	client clickhouseClient
+}
+
+// clickhouseSettingsProfileResourceModel maps the resource schema data.
+type clickhouseSettingsProfileResourceModel struct {
+	Name          types.String                    `tfsdk:"name"`
+	Inherit       types.List                      `tfsdk:"inherit"`
+	To            types.Set                       `tfsdk:"to"`
+	Settings      []clickhouseProfileSettingModel `tfsdk:"setting"`
+	QuerySettings types.Map                       `tfsdk:"query_settings"`
+	Timeouts      timeouts.Value                  `tfsdk:"timeouts"`
+}
+
+// clickhouseProfileSettingModel maps a single setting block.
+type clickhouseProfileSettingModel struct {
+	Name        types.String `tfsdk:"name"`
+	Value       types.String `tfsdk:"value"`
+	Min         types.String `tfsdk:"min"`
+	Max         types.String `tfsdk:"max"`
+	Writability types.String `tfsdk:"writability"`
+}
+
+// Metadata returns the resource type name.
+func (r *clickhouseSettingsProfileResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "clickhouse_settings_profile"
+}
+
+// Schema defines the schema for the resource.
+func (r *clickhouseSettingsProfileResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the settings profile. Changing it renames the profile in place.",
+			},
+			"inherit": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Settings profiles to inherit from, in order.",
+			},
+			"to": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Users and roles the settings profile is assigned to.",
+			},
+			"query_settings": querySettingsAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+			"setting": schema.ListNestedBlock{
+				Description: "A setting value and its constraints.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:    true,
+							Description: "The name of the setting, e.g. max_memory_usage.",
+						},
+						"value": schema.StringAttribute{
+							Optional:    true,
+							Description: "The value of the setting.",
+						},
+						"min": schema.StringAttribute{
+							Optional:    true,
+							Description: "The minimum value users may set.",
+						},
+						"max": schema.StringAttribute{
+							Optional:    true,
+							Description: "The maximum value users may set.",
+						},
+						"writability": schema.StringAttribute{
+							Optional:    true,
+							Description: "Whether users may change the setting: WRITABLE, CONST or CHANGEABLE_IN_READONLY.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks the setting constraints before they reach the server.
+func (r *clickhouseSettingsProfileResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config clickhouseSettingsProfileResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, setting := range config.Settings {
+		if setting.Writability.IsNull() || setting.Writability.IsUnknown() {
+			continue
+		}
+		writability := strings.ToUpper(setting.Writability.ValueString())
+		valid := false
+		for _, allowed := range settingWritabilities {
+			if writability == allowed {
+				valid = true
+			}
+		}
+		if !valid {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("setting").AtListIndex(i).AtName("writability"),
+				"Invalid Setting Writability",
+				"The writability must be one of "+strings.Join(settingWritabilities, ", ")+", got: "+setting.Writability.ValueString(),
+			)
+		}
+	}
+}
+
+// settingsClause renders the SETTINGS part of a settings profile statement.
+func (m *clickhouseSettingsProfileResourceModel) settingsClause(ctx context.Context, diags *diag.Diagnostics) string {
+	var elements []string
+	for _, profile := range stringsFromList(ctx, m.Inherit, diags) {
+		elements = append(elements, "INHERIT "+quoteString(profile))
+	}
+
+	for _, setting := range m.Settings {
+		element := quoteIdentifier(setting.Name.ValueString())
+		if !setting.Value.IsNull() {
+			element += " = " + settingLiteral(setting.Value.ValueString())
+		}
+		if !setting.Min.IsNull() {
+			element += " MIN " + settingLiteral(setting.Min.ValueString())
+		}
+		if !setting.Max.IsNull() {
+			element += " MAX " + settingLiteral(setting.Max.ValueString())
+		}
+		if !setting.Writability.IsNull() {
+			element += " " + strings.ToUpper(setting.Writability.ValueString())
+		}
+		elements = append(elements, element)
+	}
+
+	if len(elements) == 0 {
+		return ""
+	}
+	return " SETTINGS " + strings.Join(elements, ", ")
+}
+
+// Create handles the creation of the resource.
+func (r *clickhouseSettingsProfileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withOperation(ctx, "clickhouse_settings_profile", "create")
+
+	var plan clickhouseSettingsProfileResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, createTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, plan.QuerySettings, &resp.Diagnostics)
+
+	createProfileQuery := "CREATE SETTINGS PROFILE " + quoteIdentifier(plan.Name.ValueString()) + plan.settingsClause(ctx, &resp.Diagnostics)
+	if to := stringsFromSet(ctx, plan.To, &resp.Diagnostics); len(to) > 0 {
+		createProfileQuery += toClause(to)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.Exec(ctx, createProfileQuery); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating ClickHouse settings profile",
+			"Could not create ClickHouse settings profile, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read handles reading the resource data.
+func (r *clickhouseSettingsProfileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withOperation(ctx, "clickhouse_settings_profile", "read")
+
+	var state clickhouseSettingsProfileResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, readTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, state.QuerySettings, &resp.Diagnostics)
+
+	found, err := r.readProfile(ctx, &state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading ClickHouse settings profile",
+			"Could not read ClickHouse settings profile, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// readProfile refreshes the model from system.settings_profiles and
+// system.settings_profile_elements. It reports false when the profile is gone.
+func (r *clickhouseSettingsProfileResource) readProfile(ctx context.Context, state *clickhouseSettingsProfileResourceModel) (bool, error) {
+	name := state.Name.ValueString()
+
+	rows, err := r.client.Query(ctx, "SELECT apply_to_list FROM system.settings_profiles WHERE name = ?", name)
+	if err != nil {
+		return false, err
+	}
+	found := false
+	var applyTo []string
+	for rows.Next() {
+		found = true
+		if err := rows.Scan(&applyTo); err != nil {
+			rows.Close()
+			return false, err
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+	if !found {
+		return false, nil
+	}
+
+	rows, err = r.client.Query(ctx, `
+		SELECT setting_name, value, min, max, writability, inherit_profile
+		FROM system.settings_profile_elements
+		WHERE profile_name = ?
+		ORDER BY index`, name)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	var inherit []string
+	var settings []clickhouseProfileSettingModel
+	for rows.Next() {
+		var settingName, value, minValue, maxValue, writability, inheritProfile *string
+		if err := rows.Scan(&settingName, &value, &minValue, &maxValue, &writability, &inheritProfile); err != nil {
+			return false, err
+		}
+		if inheritProfile != nil {
+			inherit = append(inherit, *inheritProfile)
+			continue
+		}
+		if settingName == nil {
+			continue
+		}
+		settings = append(settings, clickhouseProfileSettingModel{
+			Name:        types.StringValue(*settingName),
+			Value:       stringValueOrNull(value),
+			Min:         stringValueOrNull(minValue),
+			Max:         stringValueOrNull(maxValue),
+			Writability: stringValueOrNull(writability),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+
+	// Keep the configured spelling of the writability keyword when the server
+	// reports the same constraint.
+	for i := range settings {
+		if i < len(state.Settings) && strings.EqualFold(state.Settings[i].Writability.ValueString(), settings[i].Writability.ValueString()) {
+			settings[i].Writability = state.Settings[i].Writability
+		}
+	}
+
+	state.Inherit = stringListValue(state.Inherit, inherit)
+	state.To = stringSetValue(state.To, applyTo)
+	state.Settings = settings
+	return true, nil
+}
+
+// Update handles updating the resource.
+func (r *clickhouseSettingsProfileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withOperation(ctx, "clickhouse_settings_profile", "update")
+
+	var plan, state clickhouseSettingsProfileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, updateTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, plan.QuerySettings, &resp.Diagnostics)
+
+	settings := plan.settingsClause(ctx, &resp.Diagnostics)
+	if settings == "" {
+		settings = " SETTINGS NONE"
+	}
+
+	updateProfileQuery := "ALTER SETTINGS PROFILE " + quoteIdentifier(state.Name.ValueString())
+	if plan.Name.ValueString() != state.Name.ValueString() {
+		updateProfileQuery += " RENAME TO " + quoteIdentifier(plan.Name.ValueString())
+	}
+	updateProfileQuery += settings + toClause(stringsFromSet(ctx, plan.To, &resp.Diagnostics))
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.Exec(ctx, updateProfileQuery); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating ClickHouse settings profile",
+			"Could not update ClickHouse settings profile, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete handles deleting the resource.
+func (r *clickhouseSettingsProfileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withOperation(ctx, "clickhouse_settings_profile", "delete")
+
+	var state clickhouseSettingsProfileResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, deleteTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, state.QuerySettings, &resp.Diagnostics)
+
+	deleteProfileQuery := "DROP SETTINGS PROFILE IF EXISTS " + quoteIdentifier(state.Name.ValueString())
+
+	if err := r.client.Exec(ctx, deleteProfileQuery); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting ClickHouse settings profile",
+			"Could not delete ClickHouse settings profile, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// Configure configures the resource with the provider data.
+func (r *clickhouseSettingsProfileResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(clickhouseClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected clickhouseClient, got something else",
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ImportState imports an existing settings profile by name.
+func (r *clickhouseSettingsProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = withOperation(ctx, "clickhouse_settings_profile", "import")
+
+	state := clickhouseSettingsProfileResourceModel{
+		Name:          types.StringValue(req.ID),
+		Inherit:       types.ListNull(types.StringType),
+		To:            types.SetNull(types.StringType),
+		QuerySettings: types.MapNull(types.StringType),
+		Timeouts:      nullTimeouts(),
+	}
+
+	found, err := r.readProfile(ctx, &state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing ClickHouse settings profile",
+			"Could not read ClickHouse settings profile, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if !found {
+		resp.Diagnostics.AddError(
+			"Settings profile does not exist",
+			"The ClickHouse settings profile "+req.ID+" does not exist.",
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
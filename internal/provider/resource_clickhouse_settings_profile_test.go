@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestSettingsProfileResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "clickhouse_settings_profile" "test" {
+  name = "tf_test_profile"
+
+  setting {
+    name  = "max_memory_usage"
+    value = "10000000000"
+    min   = "5000000000"
+    max   = "20000000000"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_settings_profile.test", "setting.#", "1"),
+					resource.TestCheckResourceAttr("clickhouse_settings_profile.test", "setting.0.value", "10000000000"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "clickhouse_settings_profile.test",
+				ImportState:                          true,
+				ImportStateId:                        "tf_test_profile",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "clickhouse_settings_profile" "test" {
+  name = "tf_test_profile_renamed"
+
+  setting {
+    name        = "max_memory_usage"
+    value       = "20000000000"
+    writability = "CONST"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_settings_profile.test", "name", "tf_test_profile_renamed"),
+					resource.TestCheckResourceAttr("clickhouse_settings_profile.test", "setting.0.writability", "CONST"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,416 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &clickhouseSQLResource{}
+	_ resource.ResourceWithConfigure  = &clickhouseSQLResource{}
+	_ resource.ResourceWithModifyPlan = &clickhouseSQLResource{}
+)
+
+// sqlAppliedResultKey is the private state key holding the read result
+// recorded at the last apply, which later reads are compared against.
+const sqlAppliedResultKey = "applied_result"
+
+// onClusterPlaceholder is replaced in the scripts by the ON CLUSTER clause of
+// the resource, or removed when no cluster applies.
+const onClusterPlaceholder = "{on_cluster}"
+
+// onClusterPattern matches the statements that run on a cluster, which are
+// sent as distributed DDL.
+var onClusterPattern = regexp.MustCompile(`(?i)\bON\s+CLUSTER\b`)
+
+// privateStateSetter is implemented by the private state of create and update
+// responses.
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// privateStateGetter is implemented by the private state of plan and update
+// requests.
+type privateStateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// clickhouseSQLResource is the resource implementation.
+type clickhouseSQLResource struct {
+	client clickhouseClient
+}
+
+// clickhouseSQLResourceModel maps the resource schema data.
+type clickhouseSQLResourceModel struct {
+	Create        types.String   `tfsdk:"create"`
+	Update        types.String   `tfsdk:"update"`
+	Destroy       types.String   `tfsdk:"destroy"`
+	Read          types.String   `tfsdk:"read"`
+	Result        types.Map      `tfsdk:"result"`
+	OnCluster     types.String   `tfsdk:"on_cluster"`
+	QuerySettings types.Map      `tfsdk:"query_settings"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *clickhouseSQLResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "clickhouse_sql"
+}
+
+// Schema defines the schema for the resource.
+func (r *clickhouseSQLResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Runs arbitrary SQL for objects that have no dedicated resource. " +
+			"Each script may hold several statements separated by semicolons. The provider does not rewrite the statements: " +
+			"write " + onClusterPlaceholder + " where a statement takes its ON CLUSTER clause, and it is replaced by " +
+			"ON CLUSTER and the cluster from on_cluster, or removed when no cluster applies. Statements that run ON CLUSTER " +
+			"wait for every host like the other resources' DDL.",
+		Attributes: map[string]schema.Attribute{
+			"create": schema.StringAttribute{
+				Required:    true,
+				Description: "The SQL run when the resource is created. Changing it replaces the resource unless update is set.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						requiresReplaceWithoutUpdate,
+						"Changing create replaces the resource when no update SQL is set.",
+						"Changing `create` replaces the resource when no `update` SQL is set.",
+					),
+				},
+			},
+			"update": schema.StringAttribute{
+				Optional:    true,
+				Description: "The SQL run when the configuration changes or the read result drifts.",
+			},
+			"destroy": schema.StringAttribute{
+				Optional:    true,
+				Description: "The SQL run when the resource is destroyed.",
+			},
+			"read": schema.StringAttribute{
+				Optional: true,
+				Description: "A query whose first row is kept in result. When the result changes outside of Terraform, " +
+					"update is planned, or the resource is replaced if update is not set. " +
+					"A query returning no rows marks the resource as deleted.",
+			},
+			"result": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The first row of the read query, keyed by column name.",
+			},
+			"on_cluster": schema.StringAttribute{
+				Optional: true,
+				Description: "The cluster substituted for " + onClusterPlaceholder + " in the scripts. " +
+					"Defaults to the provider cluster; set it to an empty string to run without ON CLUSTER.",
+			},
+			"query_settings": querySettingsAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+// requiresReplaceWithoutUpdate replaces the resource when create changes and
+// there is no update SQL to apply the change in place.
+func requiresReplaceWithoutUpdate(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	var update types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("update"), &update)...)
+	resp.RequiresReplace = update.IsNull()
+}
+
+// ModifyPlan plans the update SQL, or a replacement, when the read result no
+// longer matches the one recorded at the last apply.
+func (r *clickhouseSQLResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var state, plan clickhouseSQLResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || !plan.Read.Equal(state.Read) {
+		return
+	}
+
+	if !resultDrifted(ctx, &state, req.Private, &resp.Diagnostics) {
+		return
+	}
+
+	if plan.Update.IsNull() {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("result"))
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("result"), types.MapUnknown(types.StringType))...)
+}
+
+// resultDrifted reports whether the read result in state differs from the one
+// recorded at the last apply.
+func resultDrifted(ctx context.Context, state *clickhouseSQLResourceModel, private privateStateGetter, diags *diag.Diagnostics) bool {
+	if state.Read.IsNull() {
+		return false
+	}
+
+	applied, getDiags := private.GetKey(ctx, sqlAppliedResultKey)
+	diags.Append(getDiags...)
+	if diags.HasError() || applied == nil {
+		return false
+	}
+
+	var appliedResult map[string]*string
+	if err := json.Unmarshal(applied, &appliedResult); err != nil {
+		return false
+	}
+	return !state.Result.Equal(rowValue(appliedResult))
+}
+
+// execScript runs every statement of a script in order, after replacing the
+// on_cluster placeholder. Arbitrary SQL cannot be rewritten reliably, so the
+// cluster only goes where the placeholder is. Statements running ON CLUSTER
+// are sent as DDL, which waits for every host.
+func (r *clickhouseSQLResource) execScript(ctx context.Context, script string, onCluster types.String) error {
+	clause := strings.TrimSpace(onClusterClause(onCluster, r.client.Cluster()))
+	for _, statement := range splitStatements(strings.ReplaceAll(script, onClusterPlaceholder, clause)) {
+		exec := r.client.Exec
+		if onClusterPattern.MatchString(statement) {
+			exec = r.client.ExecDDL
+		}
+		if err := exec(ctx, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readResult runs the read query and returns its first row. It reports false
+// when the query returns no rows.
+func (r *clickhouseSQLResource) readResult(ctx context.Context, query string) (map[string]*string, bool, error) {
+	rows, err := r.client.Query(ctx, query)
+	if err != nil {
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, false, rows.Err()
+	}
+	row, err := scanRow(rows)
+	if err != nil {
+		return nil, false, err
+	}
+	return row, true, nil
+}
+
+// applyResult fills the result attribute after create or update and records
+// it in private state for drift detection.
+func (r *clickhouseSQLResource) applyResult(ctx context.Context, plan *clickhouseSQLResourceModel, private privateStateSetter, diags *diag.Diagnostics) {
+	if plan.Read.IsNull() {
+		plan.Result = types.MapNull(types.StringType)
+		diags.Append(private.SetKey(ctx, sqlAppliedResultKey, nil)...)
+		return
+	}
+
+	row, _, err := r.readResult(ctx, plan.Read.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error reading ClickHouse SQL result",
+			"Could not run the read query, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.Result = rowValue(row)
+
+	applied, err := json.Marshal(row)
+	if err != nil {
+		diags.AddError(
+			"Error reading ClickHouse SQL result",
+			"Could not record the read result, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	diags.Append(private.SetKey(ctx, sqlAppliedResultKey, applied)...)
+}
+
+// Create handles the creation of the resource.
+func (r *clickhouseSQLResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withOperation(ctx, "clickhouse_sql", "create")
+
+	var plan clickhouseSQLResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, createTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, plan.QuerySettings, &resp.Diagnostics)
+
+	if err := r.execScript(ctx, plan.Create.ValueString(), plan.OnCluster); err != nil {
+		resp.Diagnostics.AddError(
+			"Error running ClickHouse SQL",
+			"Could not run the create SQL, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	r.applyResult(ctx, &plan, resp.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read handles reading the resource data.
+func (r *clickhouseSQLResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withOperation(ctx, "clickhouse_sql", "read")
+
+	var state clickhouseSQLResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, readTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, state.QuerySettings, &resp.Diagnostics)
+
+	if state.Read.IsNull() {
+		return
+	}
+
+	row, found, err := r.readResult(ctx, state.Read.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading ClickHouse SQL result",
+			"Could not run the read query, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.Result = rowValue(row)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update handles updating the resource. The update SQL is run whenever the
+// statements change or the read result drifted; a change to the read query
+// or the timeouts alone only refreshes the result.
+func (r *clickhouseSQLResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withOperation(ctx, "clickhouse_sql", "update")
+
+	var plan, state clickhouseSQLResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, updateTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, plan.QuerySettings, &resp.Diagnostics)
+
+	statementsChanged := !plan.Create.Equal(state.Create) || !plan.Update.Equal(state.Update) ||
+		!plan.Destroy.Equal(state.Destroy) || !plan.OnCluster.Equal(state.OnCluster)
+	drifted := plan.Read.Equal(state.Read) && resultDrifted(ctx, &state, req.Private, &resp.Diagnostics)
+	if !plan.Update.IsNull() && (statementsChanged || drifted) {
+		if err := r.execScript(ctx, plan.Update.ValueString(), plan.OnCluster); err != nil {
+			resp.Diagnostics.AddError(
+				"Error running ClickHouse SQL",
+				"Could not run the update SQL, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	r.applyResult(ctx, &plan, resp.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete handles deleting the resource.
+func (r *clickhouseSQLResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withOperation(ctx, "clickhouse_sql", "delete")
+
+	var state clickhouseSQLResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, deleteTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, state.QuerySettings, &resp.Diagnostics)
+
+	if state.Destroy.IsNull() {
+		return
+	}
+
+	if err := r.execScript(ctx, state.Destroy.ValueString(), state.OnCluster); err != nil {
+		resp.Diagnostics.AddError(
+			"Error running ClickHouse SQL",
+			"Could not run the destroy SQL, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// Configure configures the resource with the provider data.
+func (r *clickhouseSQLResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(clickhouseClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected clickhouseClient, got something else",
+		)
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestSQLResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "clickhouse_sql" "test" {
+  create  = "CREATE TABLE default.tf_test_sql (id UInt64) ENGINE = Memory COMMENT 'v1'"
+  update  = "ALTER TABLE default.tf_test_sql MODIFY COMMENT 'v2'"
+  destroy = "DROP TABLE IF EXISTS default.tf_test_sql"
+  read    = "SELECT engine, comment FROM system.tables WHERE database = 'default' AND name = 'tf_test_sql'"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_sql.test", "result.engine", "Memory"),
+					resource.TestCheckResourceAttr("clickhouse_sql.test", "result.comment", "v1"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "clickhouse_sql" "test" {
+  create  = "CREATE TABLE default.tf_test_sql (id UInt64) ENGINE = Memory COMMENT 'v2'"
+  update  = "ALTER TABLE default.tf_test_sql MODIFY COMMENT 'v2'"
+  destroy = "DROP TABLE IF EXISTS default.tf_test_sql"
+  read    = "SELECT engine, comment FROM system.tables WHERE database = 'default' AND name = 'tf_test_sql'"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_sql.test", "result.comment", "v2"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,522 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &clickhouseTableResource{}
+	_ resource.ResourceWithConfigure      = &clickhouseTableResource{}
+	_ resource.ResourceWithValidateConfig = &clickhouseTableResource{}
+	_ resource.ResourceWithModifyPlan     = &clickhouseTableResource{}
+)
+
+// clickhouseTableResource is the resource implementation.
+type clickhouseTableResource struct {
+	client clickhouseClient
+}
+
+// clickhouseTableResourceModel maps the resource schema data.
+type clickhouseTableResourceModel struct {
+	Database      types.String            `tfsdk:"database"`
+	Name          types.String            `tfsdk:"name"`
+	OnCluster     types.String            `tfsdk:"on_cluster"`
+	Comment       types.String            `tfsdk:"comment"`
+	Settings      types.Map               `tfsdk:"settings"`
+	Columns       []clickhouseColumnModel `tfsdk:"column"`
+	Kafka         *kafkaEngineModel       `tfsdk:"kafka"`
+	RabbitMQ      *rabbitmqEngineModel    `tfsdk:"rabbitmq"`
+	NATS          *natsEngineModel        `tfsdk:"nats"`
+	S3Queue       *s3QueueEngineModel     `tfsdk:"s3queue"`
+	AzureQueue    *azureQueueEngineModel  `tfsdk:"azurequeue"`
+	S3            *s3EngineModel          `tfsdk:"s3"`
+	URL           *urlEngineModel         `tfsdk:"url"`
+	File          *fileEngineModel        `tfsdk:"file"`
+	MySQL         *mysqlEngineModel       `tfsdk:"mysql"`
+	PostgreSQL    *postgresqlEngineModel  `tfsdk:"postgresql"`
+	MongoDB       *mongodbEngineModel     `tfsdk:"mongodb"`
+	JDBC          *jdbcEngineModel        `tfsdk:"jdbc"`
+	MergeTree     *mergeTreeEngineModel   `tfsdk:"mergetree"`
+	QuerySettings types.Map               `tfsdk:"query_settings"`
+	Timeouts      timeouts.Value          `tfsdk:"timeouts"`
+}
+
+// clickhouseColumnModel maps a single column block.
+type clickhouseColumnModel struct {
+	Name    types.String `tfsdk:"name"`
+	Type    types.String `tfsdk:"type"`
+	Comment types.String `tfsdk:"comment"`
+}
+
+// engines returns the engine blocks that are set, keyed by block name.
+func (m *clickhouseTableResourceModel) engines() map[string]tableEngine {
+	engines := map[string]tableEngine{}
+	if m.Kafka != nil {
+		engines["kafka"] = m.Kafka
+	}
+	if m.RabbitMQ != nil {
+		engines["rabbitmq"] = m.RabbitMQ
+	}
+	if m.NATS != nil {
+		engines["nats"] = m.NATS
+	}
+	if m.S3Queue != nil {
+		engines["s3queue"] = m.S3Queue
+	}
+	if m.AzureQueue != nil {
+		engines["azurequeue"] = m.AzureQueue
+	}
+	if m.S3 != nil {
+		engines["s3"] = m.S3
+	}
+	if m.URL != nil {
+		engines["url"] = m.URL
+	}
+	if m.File != nil {
+		engines["file"] = m.File
+	}
+	if m.MySQL != nil {
+		engines["mysql"] = m.MySQL
+	}
+	if m.PostgreSQL != nil {
+		engines["postgresql"] = m.PostgreSQL
+	}
+	if m.MongoDB != nil {
+		engines["mongodb"] = m.MongoDB
+	}
+	if m.JDBC != nil {
+		engines["jdbc"] = m.JDBC
+	}
+	if m.MergeTree != nil {
+		engines["mergetree"] = m.MergeTree
+	}
+	return engines
+}
+
+// clearEngines unsets every engine block.
+func (m *clickhouseTableResourceModel) clearEngines() {
+	m.Kafka = nil
+	m.RabbitMQ = nil
+	m.NATS = nil
+	m.S3Queue = nil
+	m.AzureQueue = nil
+	m.S3 = nil
+	m.URL = nil
+	m.File = nil
+	m.MySQL = nil
+	m.PostgreSQL = nil
+	m.MongoDB = nil
+	m.JDBC = nil
+	m.MergeTree = nil
+}
+
+// tableName returns the quoted database.table name of the table.
+func (m *clickhouseTableResourceModel) tableName() string {
+	return quoteIdentifier(m.Database.ValueString()) + "." + quoteIdentifier(m.Name.ValueString())
+}
+
+// Metadata returns the resource type name.
+func (r *clickhouseTableResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "clickhouse_table"
+}
+
+// Schema defines the schema for the resource.
+func (r *clickhouseTableResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	requiresReplace := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+
+	resp.Schema = schema.Schema{
+		Description: "A table using one of the typed engine blocks. Exactly one engine block must be set. " +
+			"A queue table such as kafka and a mergetree table can form an ingest pipeline, but the materialized view " +
+			"moving rows between them has no resource yet and is created with clickhouse_sql.",
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Required:      true,
+				Description:   "The database of the table.",
+				PlanModifiers: requiresReplace,
+			},
+			"name": schema.StringAttribute{
+				Required:      true,
+				Description:   "The name of the table.",
+				PlanModifiers: requiresReplace,
+			},
+			"on_cluster": schema.StringAttribute{
+				Optional:      true,
+				Description:   "The cluster to run the DDL on. Defaults to the provider cluster.",
+				PlanModifiers: requiresReplace,
+			},
+			"comment": schema.StringAttribute{
+				Optional:    true,
+				Description: "The comment of the table.",
+			},
+			"settings": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Additional engine settings not covered by the engine block.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"query_settings": querySettingsAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+			"column": schema.ListNestedBlock{
+				Description: "A column of the table.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:    true,
+							Description: "The name of the column.",
+						},
+						"type": schema.StringAttribute{
+							Required:    true,
+							Description: "The type of the column.",
+						},
+						"comment": schema.StringAttribute{
+							Optional:    true,
+							Description: "The comment of the column.",
+						},
+					},
+				},
+			},
+			"kafka":      kafkaEngineBlock(),
+			"rabbitmq":   rabbitmqEngineBlock(),
+			"nats":       natsEngineBlock(),
+			"s3queue":    s3QueueEngineBlock(),
+			"azurequeue": azureQueueEngineBlock(),
+			"s3":         s3EngineBlock(),
+			"url":        urlEngineBlock(),
+			"file":       fileEngineBlock(),
+			"mysql":      mysqlEngineBlock(),
+			"postgresql": postgresqlEngineBlock(),
+			"mongodb":    mongodbEngineBlock(),
+			"jdbc":       jdbcEngineBlock(),
+			"mergetree":  mergeTreeEngineBlock(),
+		},
+	}
+}
+
+// ValidateConfig checks that exactly one engine is set and that its
+// arguments are complete, so mistakes surface at plan time.
+func (r *clickhouseTableResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config clickhouseTableResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	engines := config.engines()
+	if len(engines) != 1 {
+		resp.Diagnostics.AddError(
+			"Invalid Table Engine",
+			"Exactly one engine block must be set on a clickhouse_table resource.",
+		)
+		return
+	}
+	for name, engine := range engines {
+		engine.validate(ctx, path.Root(name), &resp.Diagnostics)
+	}
+
+	if len(config.Columns) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("column"),
+			"Missing Table Columns",
+			"At least one column block must be set.",
+		)
+	}
+}
+
+// ModifyPlan rejects engines the connected server does not support.
+func (r *clickhouseTableResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan clickhouseTableResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for name, engine := range plan.engines() {
+		for _, requirement := range engine.requirements() {
+			r.client.Server().requireCapability(path.Root(name), requirement, &resp.Diagnostics)
+		}
+	}
+}
+
+// Create handles the creation of the resource.
+func (r *clickhouseTableResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withOperation(ctx, "clickhouse_table", "create")
+
+	var plan clickhouseTableResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, createTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, plan.QuerySettings, &resp.Diagnostics)
+
+	var columns []string
+	for _, column := range plan.Columns {
+		definition := quoteIdentifier(column.Name.ValueString()) + " " + column.Type.ValueString()
+		if !column.Comment.IsNull() {
+			definition += " COMMENT " + quoteString(column.Comment.ValueString())
+		}
+		columns = append(columns, definition)
+	}
+
+	var engine string
+	settings := engineSettings{}
+	for _, block := range plan.engines() {
+		var blockSettings engineSettings
+		engine, blockSettings = block.definition(ctx, &resp.Diagnostics)
+		for name, literal := range blockSettings {
+			settings[name] = literal
+		}
+	}
+	for name, literal := range settingLiterals(stringsFromMap(ctx, plan.Settings, &resp.Diagnostics)) {
+		settings[name] = literal
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTableQuery := "CREATE TABLE " + plan.tableName() + onClusterClause(plan.OnCluster, r.client.Cluster()) +
+		" (" + strings.Join(columns, ", ") + ") ENGINE = " + engine
+	if len(settings) > 0 {
+		createTableQuery += " SETTINGS " + strings.Join(settingAssignments(settings), ", ")
+	}
+	if !plan.Comment.IsNull() {
+		createTableQuery += " COMMENT " + quoteString(plan.Comment.ValueString())
+	}
+
+	if err := r.client.ExecDDL(ctx, createTableQuery); err != nil {
+		addDDLError(&resp.Diagnostics, "Error creating ClickHouse table", "Could not create ClickHouse table", err)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read handles reading the resource data. Engine arguments are not read back
+// since they often hold credentials, but a changed engine or column set is
+// detected.
+func (r *clickhouseTableResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withOperation(ctx, "clickhouse_table", "read")
+
+	var state clickhouseTableResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, readTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, state.QuerySettings, &resp.Diagnostics)
+
+	rows, err := r.client.Query(ctx, "SELECT engine, comment FROM system.tables WHERE database = ? AND name = ?",
+		state.Database.ValueString(), state.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading ClickHouse table",
+			"Could not read ClickHouse table, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	defer rows.Close()
+
+	found := false
+	var engine, comment string
+	for rows.Next() {
+		found = true
+		if err := rows.Scan(&engine, &comment); err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading ClickHouse table",
+				"Could not read ClickHouse table, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+	if err := rows.Err(); err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading ClickHouse table",
+			"Could not read ClickHouse table, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	columns, err := r.readColumns(ctx, &state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading ClickHouse table",
+			"Could not read the columns of ClickHouse table, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	state.Columns = columns
+
+	for _, block := range state.engines() {
+		if block.engineName() != engine {
+			state.clearEngines()
+		}
+	}
+	if comment != "" || !state.Comment.IsNull() {
+		state.Comment = types.StringValue(comment)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// readColumns lists the columns of the table, keeping unset comments null.
+// The server normalizes types, e.g. Decimal(10,2) to Decimal(10, 2), so a
+// type in state that only differs in whitespace is kept as written.
+func (r *clickhouseTableResource) readColumns(ctx context.Context, state *clickhouseTableResourceModel) ([]clickhouseColumnModel, error) {
+	priorTypes := map[string]string{}
+	for _, column := range state.Columns {
+		priorTypes[column.Name.ValueString()] = column.Type.ValueString()
+	}
+
+	rows, err := r.client.Query(ctx, "SELECT name, type, comment FROM system.columns WHERE database = ? AND table = ? ORDER BY position",
+		state.Database.ValueString(), state.Name.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []clickhouseColumnModel
+	for rows.Next() {
+		var name, columnType, comment string
+		if err := rows.Scan(&name, &columnType, &comment); err != nil {
+			return nil, err
+		}
+		if prior, ok := priorTypes[name]; ok && sameExpression(prior, columnType) {
+			columnType = prior
+		}
+		column := clickhouseColumnModel{
+			Name:    types.StringValue(name),
+			Type:    types.StringValue(columnType),
+			Comment: types.StringNull(),
+		}
+		if comment != "" {
+			column.Comment = types.StringValue(comment)
+		}
+		columns = append(columns, column)
+	}
+	return columns, rows.Err()
+}
+
+// Update handles updating the resource. Only the comment can change in
+// place; every other change replaces the table.
+func (r *clickhouseTableResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withOperation(ctx, "clickhouse_table", "update")
+
+	var plan, state clickhouseTableResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, updateTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, plan.QuerySettings, &resp.Diagnostics)
+
+	if !plan.Comment.Equal(state.Comment) {
+		updateTableQuery := "ALTER TABLE " + plan.tableName() + onClusterClause(plan.OnCluster, r.client.Cluster()) + " MODIFY COMMENT " + quoteString(plan.Comment.ValueString())
+		if err := r.client.ExecDDL(ctx, updateTableQuery); err != nil {
+			addDDLError(&resp.Diagnostics, "Error updating ClickHouse table", "Could not update ClickHouse table", err)
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete handles deleting the resource.
+func (r *clickhouseTableResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withOperation(ctx, "clickhouse_table", "delete")
+
+	var state clickhouseTableResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, deleteTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, state.QuerySettings, &resp.Diagnostics)
+
+	deleteTableQuery := "DROP TABLE IF EXISTS " + state.tableName() + onClusterClause(state.OnCluster, r.client.Cluster())
+
+	if err := r.client.ExecDDL(ctx, deleteTableQuery); err != nil {
+		addDDLError(&resp.Diagnostics, "Error deleting ClickHouse table", "Could not delete ClickHouse table", err)
+		return
+	}
+}
+
+// Configure configures the resource with the provider data.
+func (r *clickhouseTableResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(clickhouseClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected clickhouseClient, got something else",
+		)
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestKafkaTableResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing. Creating a Kafka table does not contact
+			// the brokers, so a placeholder broker is enough.
+			{
+				Config: providerConfig + `
+resource "clickhouse_table" "test" {
+  database = "default"
+  name     = "tf_test_kafka_queue"
+  comment  = "ingest queue"
+
+  column {
+    name = "event_id"
+    type = "UInt64"
+  }
+
+  column {
+    name = "payload"
+    type = "String"
+  }
+
+  column {
+    name = "amount"
+    type = "Decimal(10,2)"
+  }
+
+  kafka {
+    broker_list   = ["kafka.invalid:9092"]
+    topic_list    = ["events"]
+    group_name    = "tf_test"
+    format        = "JSONEachRow"
+    sasl_username = "ingest"
+    sasl_password = "secret"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_table.test", "column.#", "3"),
+					resource.TestCheckResourceAttr("clickhouse_table.test", "column.2.type", "Decimal(10,2)"),
+					resource.TestCheckResourceAttr("clickhouse_table.test", "kafka.format", "JSONEachRow"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "clickhouse_table" "test" {
+  database = "default"
+  name     = "tf_test_kafka_queue"
+  comment  = "events ingest queue"
+
+  column {
+    name = "event_id"
+    type = "UInt64"
+  }
+
+  column {
+    name = "payload"
+    type = "String"
+  }
+
+  column {
+    name = "amount"
+    type = "Decimal(10,2)"
+  }
+
+  kafka {
+    broker_list   = ["kafka.invalid:9092"]
+    topic_list    = ["events"]
+    group_name    = "tf_test"
+    format        = "JSONEachRow"
+    sasl_username = "ingest"
+    sasl_password = "secret"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_table.test", "comment", "events ingest queue"),
+				),
+			},
+			// The server reports the type as Decimal(10, 2); the type as
+			// written must not plan a replacement.
+			{
+				Config: providerConfig + `
+resource "clickhouse_table" "test" {
+  database = "default"
+  name     = "tf_test_kafka_queue"
+  comment  = "events ingest queue"
+
+  column {
+    name = "event_id"
+    type = "UInt64"
+  }
+
+  column {
+    name = "payload"
+    type = "String"
+  }
+
+  column {
+    name = "amount"
+    type = "Decimal(10,2)"
+  }
+
+  kafka {
+    broker_list   = ["kafka.invalid:9092"]
+    topic_list    = ["events"]
+    group_name    = "tf_test"
+    format        = "JSONEachRow"
+    sasl_username = "ingest"
+    sasl_password = "secret"
+  }
+}
+`,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+// TestKafkaPipelineResource builds the usual ingest pipeline: a Kafka queue
+// table, a MergeTree table storing the rows and, since materialized views
+// have no resource yet, the view moving them created with clickhouse_sql.
+func TestKafkaPipelineResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "clickhouse_table" "queue" {
+  database = "default"
+  name     = "tf_test_pipeline_queue"
+
+  column {
+    name = "event_id"
+    type = "UInt64"
+  }
+
+  column {
+    name = "event_date"
+    type = "Date"
+  }
+
+  kafka {
+    broker_list = ["kafka.invalid:9092"]
+    topic_list  = ["events"]
+    group_name  = "tf_test_pipeline"
+    format      = "JSONEachRow"
+  }
+}
+
+resource "clickhouse_table" "events" {
+  database = "default"
+  name     = "tf_test_pipeline_events"
+
+  column {
+    name = "event_id"
+    type = "UInt64"
+  }
+
+  column {
+    name = "event_date"
+    type = "Date"
+  }
+
+  mergetree {
+    order_by     = "(event_date, event_id)"
+    partition_by = "toYYYYMM(event_date)"
+    ttl          = "event_date + INTERVAL 30 DAY"
+  }
+}
+
+resource "clickhouse_sql" "view" {
+  create  = "CREATE MATERIALIZED VIEW default.tf_test_pipeline_view TO ${clickhouse_table.events.database}.${clickhouse_table.events.name} AS SELECT event_id, event_date FROM ${clickhouse_table.queue.database}.${clickhouse_table.queue.name}"
+  destroy = "DROP VIEW IF EXISTS default.tf_test_pipeline_view"
+  read    = "SELECT as_select FROM system.tables WHERE database = 'default' AND name = 'tf_test_pipeline_view'"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_table.events", "mergetree.order_by", "(event_date, event_id)"),
+					resource.TestCheckResourceAttrSet("clickhouse_sql.view", "result.as_select"),
+				),
+			},
+		},
+	})
+}
+
+func TestMergeTreeDefinition(t *testing.T) {
+	mergeTree := &mergeTreeEngineModel{
+		OrderBy:     types.StringValue("(event_date, event_id)"),
+		PartitionBy: types.StringValue("toYYYYMM(event_date)"),
+		PrimaryKey:  types.StringNull(),
+		TTL:         types.StringValue("event_date + INTERVAL 30 DAY"),
+	}
+	engine, settings := mergeTree.definition(context.Background(), nil)
+	want := "MergeTree ORDER BY (event_date, event_id) PARTITION BY toYYYYMM(event_date) TTL event_date + INTERVAL 30 DAY"
+	if engine != want || len(settings) != 0 {
+		t.Errorf("definition() = %q, %v, want %q", engine, settings, want)
+	}
+
+	var diags diag.Diagnostics
+	mergeTree.OrderBy = types.StringNull()
+	mergeTree.validate(context.Background(), path.Root("mergetree"), &diags)
+	if !diags.HasError() {
+		t.Error("validate() accepted a mergetree block without order_by")
+	}
+}
+
+func TestEngineSettingLiterals(t *testing.T) {
+	ctx := context.Background()
+	var diags diag.Diagnostics
+	kafka := &kafkaEngineModel{
+		NamedCollection:  types.StringNull(),
+		BrokerList:       types.ListValueMust(types.StringType, []attr.Value{types.StringValue("12345")}),
+		TopicList:        types.ListValueMust(types.StringType, []attr.Value{types.StringValue("1e3")}),
+		GroupName:        types.StringValue("true"),
+		Format:           types.StringValue("JSONEachRow"),
+		NumConsumers:     types.Int64Value(2),
+		SecurityProtocol: types.StringNull(),
+		SaslMechanism:    types.StringNull(),
+		SaslUsername:     types.StringValue("ingest"),
+		SaslPassword:     types.StringValue("12345"),
+	}
+	_, settings := kafka.definition(ctx, &diags)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	want := "kafka_broker_list = '12345', kafka_format = 'JSONEachRow', kafka_group_name = 'true', kafka_num_consumers = 2, " +
+		"kafka_sasl_password = '12345', kafka_sasl_username = 'ingest', kafka_topic_list = '1e3'"
+	if got := strings.Join(settingAssignments(settings), ", "); got != want {
+		t.Errorf("settingAssignments() = %q, want %q", got, want)
+	}
+
+	got := settingLiterals(map[string]string{
+		"kafka_sasl_password":  "12345",
+		"kafka_max_block_size": "1048576",
+		"flag":                 "true",
+		"ratio":                "-0.5",
+		"inf":                  "inf",
+		"nan":                  "NaN",
+		"exponent":             "1e3",
+		"hex":                  "0x1p-2",
+		"padded":               " 1",
+	})
+	wantLiterals := map[string]string{
+		"kafka_sasl_password":  "'12345'",
+		"kafka_max_block_size": "1048576",
+		"flag":                 "true",
+		"ratio":                "-0.5",
+		"inf":                  "'inf'",
+		"nan":                  "'NaN'",
+		"exponent":             "'1e3'",
+		"hex":                  "'0x1p-2'",
+		"padded":               "' 1'",
+	}
+	if !reflect.DeepEqual(got, wantLiterals) {
+		t.Errorf("settingLiterals() = %v, want %v", got, wantLiterals)
+	}
+}
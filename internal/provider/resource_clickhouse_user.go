@@ -4,7 +4,7 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -19,13 +19,15 @@ var (
 
 // clickhouseUserResource is the resource implementation.
 type clickhouseUserResource struct {
-	client clickhouse.Conn
+	client clickhouseClient
 }
 
 // clickhouseUserResourceModel maps the resource schema data.
 type clickhouseUserResourceModel struct {
-	Username types.String `tfsdk:"username"`
-	Password types.String `tfsdk:"password"`
+	Username      types.String   `tfsdk:"username"`
+	Password      types.String   `tfsdk:"password"`
+	QuerySettings types.Map      `tfsdk:"query_settings"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Metadata returns the resource type name.
@@ -34,7 +36,7 @@ func (r *clickhouseUserResource) Metadata(_ context.Context, _ resource.Metadata
 }
 
 // Schema defines the schema for the resource.
-func (r *clickhouseUserResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *clickhouseUserResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"username": schema.StringAttribute{
@@ -46,12 +48,18 @@ func (r *clickhouseUserResource) Schema(_ context.Context, _ resource.SchemaRequ
 				Description: "The password of the ClickHouse user.",
 				Sensitive:   true,
 			},
+			"query_settings": querySettingsAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
 		},
 	}
 }
 
 // Create handles the creation of the resource.
 func (r *clickhouseUserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withOperation(ctx, "clickhouse_user", "create")
+
 	var plan clickhouseUserResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -59,10 +67,19 @@ func (r *clickhouseUserResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, createTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, plan.QuerySettings, &resp.Diagnostics)
+
 	createUserQuery := fmt.Sprintf(
-		"CREATE USER %s IDENTIFIED BY '%s'",
+		"CREATE USER %s IDENTIFIED BY %s",
 		plan.Username.ValueString(),
-		plan.Password.ValueString(),
+		quoteString(plan.Password.ValueString()),
 	)
 
 	if err := r.client.Exec(ctx, createUserQuery); err != nil {
@@ -79,6 +96,8 @@ func (r *clickhouseUserResource) Create(ctx context.Context, req resource.Create
 
 // Read handles reading the resource data.
 func (r *clickhouseUserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withOperation(ctx, "clickhouse_user", "read")
+
 	var state clickhouseUserResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -86,6 +105,15 @@ func (r *clickhouseUserResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, readTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, state.QuerySettings, &resp.Diagnostics)
+
 	// Properly format the query to check if the user exists, enclosing the username in single quotes
 	query := fmt.Sprintf("SELECT count() > 0 FROM system.users WHERE name = '%s'", state.Username.ValueString())
 	var exists bool
@@ -113,6 +141,8 @@ func (r *clickhouseUserResource) Read(ctx context.Context, req resource.ReadRequ
 
 // Update handles updating the resource.
 func (r *clickhouseUserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withOperation(ctx, "clickhouse_user", "update")
+
 	var plan clickhouseUserResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -120,10 +150,19 @@ func (r *clickhouseUserResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, updateTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, plan.QuerySettings, &resp.Diagnostics)
+
 	updateUserQuery := fmt.Sprintf(
-		"ALTER USER %s IDENTIFIED BY '%s'",
+		"ALTER USER %s IDENTIFIED BY %s",
 		plan.Username.ValueString(),
-		plan.Password.ValueString(),
+		quoteString(plan.Password.ValueString()),
 	)
 
 	if err := r.client.Exec(ctx, updateUserQuery); err != nil {
@@ -140,6 +179,8 @@ func (r *clickhouseUserResource) Update(ctx context.Context, req resource.Update
 
 // Delete handles deleting the resource.
 func (r *clickhouseUserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withOperation(ctx, "clickhouse_user", "delete")
+
 	var state clickhouseUserResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -147,6 +188,15 @@ func (r *clickhouseUserResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, deleteTimeout)
+	defer cancel()
+	ctx = withResourceSettings(ctx, state.QuerySettings, &resp.Diagnostics)
+
 	deleteUserQuery := fmt.Sprintf("DROP USER %s", state.Username.ValueString())
 
 	if err := r.client.Exec(ctx, deleteUserQuery); err != nil {
@@ -164,11 +214,11 @@ func (r *clickhouseUserResource) Configure(ctx context.Context, req resource.Con
 		return
 	}
 
-	client, ok := req.ProviderData.(clickhouse.Conn)
+	client, ok := req.ProviderData.(clickhouseClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			"Expected clickhouse.Conn, got something else",
+			"Expected clickhouseClient, got something else",
 		)
 		return
 	}
@@ -177,6 +227,8 @@ func (r *clickhouseUserResource) Configure(ctx context.Context, req resource.Con
 }
 
 func (r *clickhouseUserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = withOperation(ctx, "clickhouse_user", "import")
+
 	// Retrieve the import ID (username) from the request
 	username := req.ID
 
@@ -202,7 +254,9 @@ func (r *clickhouseUserResource) ImportState(ctx context.Context, req resource.I
 
 	// If the user exists, set the state with the username
 	state := clickhouseUserResourceModel{
-		Username: types.StringValue(username),
+		Username:      types.StringValue(username),
+		QuerySettings: types.MapNull(types.StringType),
+		Timeouts:      nullTimeouts(),
 		// Note: Password is not retrieved during import for security reasons
 	}
 
@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"syscall"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultMaxRetries   = 3
+	defaultRetryTimeout = 5 * time.Minute
+)
+
+// retryableExceptionCodes are the server error codes of failures that are
+// expected to go away on their own: overload, network trouble between
+// replicas, and ZooKeeper or Keeper sessions being lost during replicated DDL.
+var retryableExceptionCodes = map[int32]bool{
+	198: true, // DNS_ERROR
+	202: true, // TOO_MANY_SIMULTANEOUS_QUERIES
+	209: true, // SOCKET_TIMEOUT
+	210: true, // NETWORK_ERROR
+	225: true, // NO_ZOOKEEPER
+	242: true, // TABLE_IS_READ_ONLY
+	279: true, // ALL_CONNECTION_TRIES_FAILED
+	373: true, // SESSION_IS_LOCKED
+	425: true, // SYSTEM_ERROR
+	439: true, // CANNOT_SCHEDULE_TASK
+	999: true, // KEEPER_EXCEPTION
+}
+
+// retryPolicy decides how often and for how long a failed statement is
+// retried.
+type retryPolicy struct {
+	maxRetries int
+	timeout    time.Duration
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+}
+
+// defaultRetryPolicy returns the policy with the given limits and the usual
+// backoff of half a second doubling up to half a minute.
+func defaultRetryPolicy(maxRetries int, timeout time.Duration) retryPolicy {
+	return retryPolicy{
+		maxRetries: maxRetries,
+		timeout:    timeout,
+		baseDelay:  500 * time.Millisecond,
+		maxDelay:   30 * time.Second,
+	}
+}
+
+// isRetryable reports whether an error is transient. Server exceptions are
+// classified by code; anything else is retried only when it is a network
+// failure.
+func isRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var exception *clickhouse.Exception
+	if errors.As(err, &exception) {
+		return retryableExceptionCodes[exception.Code]
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, clickhouse.ErrAcquireConnTimeout) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE)
+}
+
+// rejectedExceptionCodes are the server error codes of statements refused
+// before they started running, which are safe to send again whatever they
+// do.
+var rejectedExceptionCodes = map[int32]bool{
+	202: true, // TOO_MANY_SIMULTANEOUS_QUERIES
+	439: true, // CANNOT_SCHEDULE_TASK
+}
+
+// keeperExceptionCodes are the server error codes of ZooKeeper or Keeper
+// sessions being lost, which replicated and ON CLUSTER DDL recovers from when
+// retried.
+var keeperExceptionCodes = map[int32]bool{
+	225: true, // NO_ZOOKEEPER
+	242: true, // TABLE_IS_READ_ONLY
+	999: true, // KEEPER_EXCEPTION
+}
+
+// isRejected reports whether a statement failed without being run: the
+// server could not be reached, or it refused the statement up front.
+func isRejected(err error) bool {
+	var exception *clickhouse.Exception
+	if errors.As(err, &exception) {
+		return rejectedExceptionCodes[exception.Code]
+	}
+	return isConnectError(err)
+}
+
+// isRetryableDDL reports whether a DDL statement may be sent again: it was
+// rejected, or it failed on a lost Keeper session.
+func isRetryableDDL(err error) bool {
+	var exception *clickhouse.Exception
+	if errors.As(err, &exception) && keeperExceptionCodes[exception.Code] {
+		return true
+	}
+	return isRejected(err)
+}
+
+// isConnectError reports whether an error happened while reaching the
+// server, before any statement was sent.
+func isConnectError(err error) bool {
+	var (
+		dnsErr *net.DNSError
+		opErr  *net.OpError
+	)
+	return errors.As(err, &dnsErr) ||
+		errors.As(err, &opErr) && opErr.Op == "dial" ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, clickhouse.ErrAcquireConnTimeout)
+}
+
+// backoff returns the delay before the given retry, counted from one.
+func (p retryPolicy) backoff(retry int) time.Duration {
+	delay := p.baseDelay
+	for i := 1; i < retry && delay < p.maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, p.maxDelay)
+}
+
+// do runs fn until it succeeds, fails with an error that retryable rejects,
+// or the retries or the retry timeout are used up.
+func (p retryPolicy) do(ctx context.Context, retryable func(error) bool, fn func() error) error {
+	deadline := time.Now().Add(p.timeout)
+	for retry := 1; ; retry++ {
+		err := fn()
+		if retry > p.maxRetries || !retryable(err) {
+			return err
+		}
+
+		delay := p.backoff(retry)
+		if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+			deadline = ctxDeadline
+		}
+		if time.Now().Add(delay).After(deadline) {
+			return err
+		}
+
+		fields := map[string]any{
+			"attempt":     retry,
+			"max_retries": p.maxRetries,
+			"delay":       delay.String(),
+			"error":       err.Error(),
+		}
+		var exception *clickhouse.Exception
+		if errors.As(err, &exception) {
+			fields["code"] = exception.Code
+		}
+		tflog.Warn(ctx, "Retrying ClickHouse statement after a transient error", fields)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+)
+
+func TestIsRetryable(t *testing.T) {
+	for _, test := range []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{&clickhouse.Exception{Code: 202, Name: "TOO_MANY_SIMULTANEOUS_QUERIES"}, true},
+		{fmt.Errorf("create: %w", &clickhouse.Exception{Code: 999, Name: "KEEPER_EXCEPTION"}), true},
+		{&clickhouse.Exception{Code: 57, Name: "TABLE_ALREADY_EXISTS"}, false},
+		{&clickhouse.Exception{Code: 516, Name: "AUTHENTICATION_FAILED"}, false},
+		{&net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, true},
+		{fmt.Errorf("read: %w", syscall.ECONNRESET), true},
+		{clickhouse.ErrAcquireConnTimeout, true},
+		{context.DeadlineExceeded, false},
+		{errors.New("syntax error"), false},
+	} {
+		if got := isRetryable(test.err); got != test.want {
+			t.Errorf("isRetryable(%v) = %t, want %t", test.err, got, test.want)
+		}
+	}
+}
+
+func TestIsConnectError(t *testing.T) {
+	for _, test := range []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{&net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, true},
+		{&net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "ch.invalid"}}, true},
+		{clickhouse.ErrAcquireConnTimeout, true},
+		{&net.OpError{Op: "read", Err: syscall.ECONNRESET}, false},
+		{io.EOF, false},
+		{&clickhouse.Exception{Code: 319, Name: "UNKNOWN_STATUS_OF_INSERT"}, false},
+		{&clickhouse.Exception{Code: 202, Name: "TOO_MANY_SIMULTANEOUS_QUERIES"}, false},
+	} {
+		if got := isConnectError(test.err); got != test.want {
+			t.Errorf("isConnectError(%v) = %t, want %t", test.err, got, test.want)
+		}
+	}
+}
+
+// flakyConn fails its statements with the given errors, one per attempt.
+type flakyConn struct {
+	driver.Conn
+	errs     []error
+	attempts int
+}
+
+func (c *flakyConn) Ping(context.Context) error { return nil }
+
+func (c *flakyConn) Exec(context.Context, string, ...any) error {
+	c.attempts++
+	return c.errs[c.attempts-1]
+}
+
+func TestExecNotRetriedAfterSend(t *testing.T) {
+	policy := retryPolicy{maxRetries: 3, timeout: time.Minute, baseDelay: time.Millisecond, maxDelay: time.Millisecond}
+	ctx := context.Background()
+
+	conn := &flakyConn{errs: []error{io.EOF, nil}}
+	client := &providerClient{conn: conn, retry: policy}
+	if err := client.Exec(ctx, "INSERT INTO t VALUES (1)"); !errors.Is(err, io.EOF) || conn.attempts != 1 {
+		t.Errorf("Exec() = %v after %d attempts, want the connection error after 1", err, conn.attempts)
+	}
+
+	conn = &flakyConn{errs: []error{&net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, nil}}
+	client = &providerClient{conn: conn, retry: policy}
+	if err := client.Exec(ctx, "INSERT INTO t VALUES (1)"); err != nil || conn.attempts != 2 {
+		t.Errorf("Exec() = %v after %d attempts, want success after 2", err, conn.attempts)
+	}
+
+	rejected := &clickhouse.Exception{Code: 202, Name: "TOO_MANY_SIMULTANEOUS_QUERIES"}
+	conn = &flakyConn{errs: []error{rejected, io.EOF, nil}}
+	client = &providerClient{conn: conn, retry: policy}
+	if err := client.Exec(ctx, "INSERT INTO t VALUES (1)"); !errors.Is(err, io.EOF) || conn.attempts != 2 {
+		t.Errorf("Exec() = %v after %d attempts, want the connection error after 2", err, conn.attempts)
+	}
+
+	keeper := &clickhouse.Exception{Code: 999, Name: "KEEPER_EXCEPTION"}
+	if isRejected(keeper) || !isRetryableDDL(keeper) || !isRetryableDDL(rejected) || isRetryableDDL(io.EOF) {
+		t.Error("a Keeper error must only be retried for DDL, and a sent DDL statement never")
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := retryPolicy{baseDelay: time.Second, maxDelay: 5 * time.Second}
+	for retry, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second, 10: 5 * time.Second} {
+		if got := p.backoff(retry); got != want {
+			t.Errorf("backoff(%d) = %s, want %s", retry, got, want)
+		}
+	}
+}
+
+func TestRetryPolicyDo(t *testing.T) {
+	transient := &clickhouse.Exception{Code: 210, Name: "NETWORK_ERROR"}
+	fatal := &clickhouse.Exception{Code: 62, Name: "SYNTAX_ERROR"}
+	p := retryPolicy{maxRetries: 3, timeout: time.Minute, baseDelay: time.Millisecond, maxDelay: time.Millisecond}
+	ctx := context.Background()
+
+	for _, test := range []struct {
+		name     string
+		policy   retryPolicy
+		errs     []error
+		wantErr  error
+		wantRuns int
+	}{
+		{"succeeds after transient errors", p, []error{transient, transient, nil}, nil, 3},
+		{"stops on fatal error", p, []error{transient, fatal, nil}, fatal, 2},
+		{"gives up after max retries", p, []error{transient, transient, transient, transient, transient}, transient, 4},
+		{"retries disabled", retryPolicy{timeout: time.Minute}, []error{transient, nil}, transient, 1},
+		{"retry timeout exceeded", retryPolicy{maxRetries: 3, timeout: time.Millisecond, baseDelay: time.Second, maxDelay: time.Second}, []error{transient, nil}, transient, 1},
+	} {
+		runs := 0
+		err := test.policy.do(ctx, isRetryable, func() error {
+			runs++
+			return test.errs[runs-1]
+		})
+		if !errors.Is(err, test.wantErr) || runs != test.wantRuns {
+			t.Errorf("%s: got %v after %d runs, want %v after %d", test.name, err, runs, test.wantErr, test.wantRuns)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// serverInfo describes the ClickHouse server the provider is connected to.
+// When the version could not be detected every capability is assumed to be
+// supported and the server is left to reject what it does not understand.
+type serverInfo struct {
+	version      string
+	major        int
+	minor        int
+	patch        int
+	buildOptions map[string]string
+}
+
+// capability is a server feature that a resource relies on. It is available
+// from the given release, and only when the server was built with
+// buildOption if one is named.
+type capability struct {
+	name        string
+	description string
+	major       int
+	minor       int
+	buildOption string
+}
+
+var (
+	capNamedCollectionDDL         = capability{"named_collection_ddl", "Named collections managed with SQL", 23, 1, ""}
+	capNamedCollectionOverridable = capability{"named_collection_overridable", "OVERRIDABLE named collection keys", 24, 3, ""}
+	capKafkaEngine                = capability{"kafka_engine", "The Kafka table engine", 0, 0, "USE_RDKAFKA"}
+	capRabbitMQEngine             = capability{"rabbitmq_engine", "The RabbitMQ table engine", 0, 0, "USE_AMQPCPP"}
+	capNATSEngine                 = capability{"nats_engine", "The NATS table engine", 22, 4, "USE_NATSIO"}
+	capS3Engine                   = capability{"s3_engine", "The S3 table engine", 0, 0, "USE_AWS_S3"}
+	capS3QueueEngine              = capability{"s3queue_engine", "The S3Queue table engine", 23, 8, "USE_AWS_S3"}
+	capAzureQueueEngine           = capability{"azurequeue_engine", "The AzureQueue table engine", 24, 7, "USE_AZURE_BLOB_STORAGE"}
+	capMySQLEngine                = capability{"mysql_engine", "The MySQL table engine", 0, 0, "USE_MYSQL"}
+	capPostgreSQLEngine           = capability{"postgresql_engine", "The PostgreSQL table engine", 0, 0, "USE_LIBPQXX"}
+	capMongoDBEngine              = capability{"mongodb_engine", "The MongoDB table engine", 0, 0, "USE_MONGODB"}
+	capMongoDBURI                 = capability{"mongodb_uri", "MongoDB connection URIs", 24, 10, "USE_MONGODB"}
+
+	// allCapabilities lists every capability reported by the
+	// clickhouse_server_info data source.
+	allCapabilities = []capability{
+		capNamedCollectionDDL,
+		capNamedCollectionOverridable,
+		capKafkaEngine,
+		capRabbitMQEngine,
+		capNATSEngine,
+		capS3Engine,
+		capS3QueueEngine,
+		capAzureQueueEngine,
+		capMySQLEngine,
+		capPostgreSQLEngine,
+		capMongoDBEngine,
+		capMongoDBURI,
+	}
+)
+
+// detectServerInfo reads the server version and build options.
+func detectServerInfo(ctx context.Context, client clickhouseClient) (*serverInfo, error) {
+	info := &serverInfo{buildOptions: map[string]string{}}
+	if err := client.QueryRow(ctx, "SELECT version()").Scan(&info.version); err != nil {
+		return nil, err
+	}
+	info.major, info.minor, info.patch = parseServerVersion(info.version)
+
+	rows, err := client.Query(ctx, "SELECT name, value FROM system.build_options")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, value string
+		if err := rows.Scan(&name, &value); err != nil {
+			return nil, err
+		}
+		info.buildOptions[name] = value
+	}
+	return info, rows.Err()
+}
+
+// parseServerVersion extracts the major, minor and patch numbers from a
+// version such as 24.8.4.13.
+func parseServerVersion(version string) (int, int, int) {
+	var numbers [3]int
+	for i, part := range strings.SplitN(version, ".", 4) {
+		if i == len(numbers) {
+			break
+		}
+		numbers[i], _ = strconv.Atoi(part)
+	}
+	return numbers[0], numbers[1], numbers[2]
+}
+
+// known reports whether the server version was detected.
+func (s *serverInfo) known() bool {
+	return s != nil && s.version != ""
+}
+
+// atLeast reports whether the server runs the given release or a later one.
+func (s *serverInfo) atLeast(major, minor int) bool {
+	return s.major > major || (s.major == major && s.minor >= minor)
+}
+
+// builtWith reports whether a build option is enabled. Options the server
+// does not report are assumed to be enabled.
+func (s *serverInfo) builtWith(option string) bool {
+	value, found := s.buildOptions[option]
+	if !found {
+		return true
+	}
+	switch strings.ToUpper(value) {
+	case "1", "ON", "TRUE", "YES":
+		return true
+	}
+	return false
+}
+
+// supports reports whether the server provides a capability.
+func (s *serverInfo) supports(c capability) bool {
+	if !s.known() {
+		return true
+	}
+	return s.atLeast(c.major, c.minor) && (c.buildOption == "" || s.builtWith(c.buildOption))
+}
+
+// requireCapability reports an attribute that needs a capability the server
+// lacks, naming the minimum version or the missing build option.
+func (s *serverInfo) requireCapability(attributePath path.Path, c capability, diags *diag.Diagnostics) {
+	if s.supports(c) {
+		return
+	}
+
+	var detail string
+	if !s.atLeast(c.major, c.minor) {
+		detail = fmt.Sprintf("%s requires ClickHouse %d.%d or later, but the server runs %s.", c.description, c.major, c.minor, s.version)
+	} else {
+		detail = fmt.Sprintf("%s requires a ClickHouse server built with %s, but it is disabled on this server.", c.description, c.buildOption)
+	}
+	diags.AddAttributeError(attributePath, "Unsupported ClickHouse Server Feature", detail)
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestParseServerVersion(t *testing.T) {
+	for version, want := range map[string][3]int{
+		"24.8.4.13":     {24, 8, 4},
+		"23.3.1.2823":   {23, 3, 1},
+		"25.1":          {25, 1, 0},
+		"24.10.1.2812a": {24, 10, 1},
+	} {
+		major, minor, patch := parseServerVersion(version)
+		if got := [3]int{major, minor, patch}; got != want {
+			t.Errorf("parseServerVersion(%q) = %v, want %v", version, got, want)
+		}
+	}
+}
+
+func TestServerInfoRequireCapability(t *testing.T) {
+	old := &serverInfo{version: "23.8.2.7", major: 23, minor: 8, buildOptions: map[string]string{"USE_AWS_S3": "1"}}
+	noAzure := &serverInfo{version: "24.8.1.1", major: 24, minor: 8, buildOptions: map[string]string{"USE_AZURE_BLOB_STORAGE": "0"}}
+	unknown := &serverInfo{}
+
+	var diags diag.Diagnostics
+	old.requireCapability(path.Root("s3queue"), capS3QueueEngine, &diags)
+	unknown.requireCapability(path.Root("azurequeue"), capAzureQueueEngine, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	old.requireCapability(path.Root("azurequeue"), capAzureQueueEngine, &diags)
+	if diags.ErrorsCount() != 1 || !strings.Contains(diags[0].Detail(), "24.7 or later") {
+		t.Fatalf("expected a minimum version diagnostic, got %v", diags)
+	}
+
+	diags = nil
+	noAzure.requireCapability(path.Root("azurequeue"), capAzureQueueEngine, &diags)
+	if diags.ErrorsCount() != 1 || !strings.Contains(diags[0].Detail(), "USE_AZURE_BLOB_STORAGE") {
+		t.Fatalf("expected a build option diagnostic, got %v", diags)
+	}
+}
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// settingsPriority orders the sources of query settings. When several set the
+// same setting, the one with the highest priority wins.
+type settingsPriority int
+
+const (
+	// defaultSettings are chosen by the provider, such as the limits derived
+	// from timeouts.
+	defaultSettings settingsPriority = iota
+	// providerSettings are the settings of the provider configuration.
+	providerSettings
+	// resourceSettings are the query_settings of a resource.
+	resourceSettings
+	// requiredSettings must hold whatever the configuration says, such as
+	// readonly for the clickhouse_query data source.
+	requiredSettings
+
+	settingsPriorities
+)
+
+// querySettingsKey is the context key holding the settings added with
+// withQuerySettings.
+type querySettingsKey struct{}
+
+// querySettingsLayers holds the settings attached to a context, by priority.
+type querySettingsLayers [settingsPriorities]clickhouse.Settings
+
+// withQuerySettings returns a context whose statements run with the given
+// settings on top of those of the same priority already attached to ctx.
+func withQuerySettings(ctx context.Context, priority settingsPriority, settings clickhouse.Settings) context.Context {
+	layers, _ := ctx.Value(querySettingsKey{}).(querySettingsLayers)
+	merged := clickhouse.Settings{}
+	for name, value := range layers[priority] {
+		merged[name] = value
+	}
+	for name, value := range settings {
+		merged[name] = value
+	}
+	layers[priority] = merged
+	return context.WithValue(ctx, querySettingsKey{}, layers)
+}
+
+// querySettings merges the settings attached to ctx with the provider
+// settings.
+func querySettings(ctx context.Context, provider clickhouse.Settings) clickhouse.Settings {
+	layers, _ := ctx.Value(querySettingsKey{}).(querySettingsLayers)
+	layers[providerSettings] = provider
+
+	merged := clickhouse.Settings{}
+	for _, layer := range layers {
+		for name, value := range layer {
+			merged[name] = value
+		}
+	}
+	return merged
+}
+
+// queryContext hands the settings of the statement to the driver, which is
+// given a map of its own as it writes to it.
+func (c *providerClient) queryContext(ctx context.Context) context.Context {
+	settings := querySettings(ctx, c.settings)
+	if len(settings) == 0 {
+		return ctx
+	}
+	return clickhouse.Context(ctx, clickhouse.WithSettings(settings))
+}
+
+// querySettingsAttribute returns the query_settings attribute shared by all
+// resources.
+func querySettingsAttribute() schema.MapAttribute {
+	return schema.MapAttribute{
+		ElementType: types.StringType,
+		Optional:    true,
+		Description: "Settings applied to the statements this resource runs, such as mutations_sync or allow_experimental_* flags. " +
+			"They take precedence over the provider settings.",
+	}
+}
+
+// withResourceSettings attaches the query_settings of a resource to ctx.
+func withResourceSettings(ctx context.Context, querySettings types.Map, diags *diag.Diagnostics) context.Context {
+	settings := clickhouse.Settings{}
+	for name, value := range stringsFromMap(ctx, querySettings, diags) {
+		settings[name] = value
+	}
+	return withQuerySettings(ctx, resourceSettings, settings)
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestQuerySettingsPrecedence(t *testing.T) {
+	provider := clickhouse.Settings{"mutations_sync": "1", "distributed_ddl_output_mode": "throw", "readonly": "0"}
+
+	ctx := context.Background()
+	if got := querySettings(ctx, provider); !reflect.DeepEqual(got, provider) {
+		t.Errorf("querySettings() without attached settings = %v, want the provider settings", got)
+	}
+
+	ctx, cancel := withOperationTimeout(ctx, 90*time.Second)
+	defer cancel()
+	ctx = withQuerySettings(ctx, defaultSettings, clickhouse.Settings{"distributed_ddl_output_mode": "never_throw"})
+
+	var diags diag.Diagnostics
+	ctx = withResourceSettings(ctx, types.MapValueMust(types.StringType, map[string]attr.Value{
+		"mutations_sync": types.StringValue("2"),
+	}), &diags)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	ctx = withQuerySettings(ctx, requiredSettings, clickhouse.Settings{"readonly": 1})
+
+	want := clickhouse.Settings{
+		"max_execution_time":           90,
+		"distributed_ddl_task_timeout": 90,
+		"distributed_ddl_output_mode":  "throw",
+		"mutations_sync":               "2",
+		"readonly":                     1,
+	}
+	if got := querySettings(ctx, provider); !reflect.DeepEqual(got, want) {
+		t.Errorf("querySettings() = %v, want %v", got, want)
+	}
+}
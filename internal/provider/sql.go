@@ -0,0 +1,268 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// quoteIdentifier wraps a ClickHouse identifier in backticks, escaping any
+// backticks or backslashes it contains.
+func quoteIdentifier(name string) string {
+	replacer := strings.NewReplacer("\\", "\\\\", "`", "\\`")
+	return "`" + replacer.Replace(name) + "`"
+}
+
+// quoteIdentifiers quotes every name and joins them with commas.
+func quoteIdentifiers(names []string) string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, quoteIdentifier(name))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// quoteString renders a value as a single-quoted ClickHouse string literal.
+func quoteString(value string) string {
+	replacer := strings.NewReplacer("\\", "\\\\", "'", "\\'")
+	return "'" + replacer.Replace(value) + "'"
+}
+
+// numberLiteralPattern matches the plain decimal numbers a setting value may
+// be written as without quotes. Forms such as inf, nan, 1e3 or hex floats
+// are quoted.
+var numberLiteralPattern = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// settingLiteral renders a setting value, leaving numbers and booleans bare so
+// the server reports them back the same way they were written.
+func settingLiteral(value string) string {
+	if numberLiteralPattern.MatchString(value) || value == "true" || value == "false" {
+		return value
+	}
+	return quoteString(value)
+}
+
+// settingLiterals renders free-form setting values with settingLiteral.
+// Credentials are always quoted since their type cannot be told from their
+// value.
+func settingLiterals(settings map[string]string) map[string]string {
+	literals := make(map[string]string, len(settings))
+	for name, value := range settings {
+		if secretSettingPattern.MatchString(name) {
+			literals[name] = quoteString(value)
+		} else {
+			literals[name] = settingLiteral(value)
+		}
+	}
+	return literals
+}
+
+// toClause renders the TO part of an access entity statement. An empty list
+// clears the assignment with TO NONE.
+func toClause(grantees []string) string {
+	if len(grantees) == 0 {
+		return " TO NONE"
+	}
+	return " TO " + quoteIdentifiers(grantees)
+}
+
+// quoteTableName quotes a "database.table" reference. A table of * is kept
+// bare so that policies can cover a whole database.
+func quoteTableName(name string) string {
+	database, table, found := strings.Cut(name, ".")
+	if !found {
+		return quoteIdentifier(name)
+	}
+	if table == "*" {
+		return quoteIdentifier(database) + ".*"
+	}
+	return quoteIdentifier(database) + "." + quoteIdentifier(table)
+}
+
+// onClusterClause renders the ON CLUSTER part of a DDL statement. The
+// provider's default cluster is used when the resource does not set one.
+func onClusterClause(onCluster types.String, defaultCluster string) string {
+	cluster := defaultCluster
+	if !onCluster.IsNull() {
+		cluster = onCluster.ValueString()
+	}
+	if cluster == "" {
+		return ""
+	}
+	return " ON CLUSTER " + quoteIdentifier(cluster)
+}
+
+// sameExpression reports whether two SQL expressions only differ in
+// whitespace outside quoted literals, which is how the server reformats
+// expressions it stores.
+func sameExpression(a, b string) bool {
+	return stripSpace(a) == stripSpace(b)
+}
+
+// stripSpace removes the whitespace of an expression, keeping the content of
+// string literals and quoted identifiers as written.
+func stripSpace(expression string) string {
+	var stripped strings.Builder
+	var quote rune
+	escaped := false
+	for _, r := range expression {
+		switch {
+		case escaped:
+			escaped = false
+		case quote != 0 && r == '\\':
+			escaped = true
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case unicode.IsSpace(r):
+			continue
+		}
+		stripped.WriteRune(r)
+	}
+	return stripped.String()
+}
+
+// parseEngineSettings extracts the SETTINGS clause of an engine_full value
+// from system.tables. Quoted values are returned without their quotes.
+func parseEngineSettings(engineFull string) map[string]string {
+	_, clause, found := strings.Cut(engineFull, " SETTINGS ")
+	if !found {
+		return nil
+	}
+
+	settings := map[string]string{}
+	for _, element := range splitTopLevel(clause) {
+		name, value, found := strings.Cut(element, "=")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") {
+			value = strings.NewReplacer("\\'", "'", "\\\\", "\\").Replace(value[1 : len(value)-1])
+		}
+		settings[strings.TrimSpace(name)] = value
+	}
+	return settings
+}
+
+// splitTopLevel splits a comma separated list, ignoring commas inside quotes
+// and parentheses.
+func splitTopLevel(list string) []string {
+	var elements []string
+	depth := 0
+	quoted := false
+	start := 0
+	for i := 0; i < len(list); i++ {
+		switch c := list[i]; {
+		case c == '\\' && quoted:
+			i++
+		case c == '\'':
+			quoted = !quoted
+		case quoted:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			elements = append(elements, strings.TrimSpace(list[start:i]))
+			start = i + 1
+		}
+	}
+	if rest := strings.TrimSpace(list[start:]); rest != "" {
+		elements = append(elements, rest)
+	}
+	return elements
+}
+
+// splitStatements splits a script into its semicolon separated statements,
+// ignoring semicolons inside quoted strings and identifiers.
+func splitStatements(script string) []string {
+	var statements []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(script); i++ {
+		switch c := script[i]; {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == ';':
+			if statement := strings.TrimSpace(script[start:i]); statement != "" {
+				statements = append(statements, statement)
+			}
+			start = i + 1
+		}
+	}
+	if statement := strings.TrimSpace(script[start:]); statement != "" {
+		statements = append(statements, statement)
+	}
+	return statements
+}
+
+// scanRow scans the current row into a map of column name to its value
+// rendered as a string, using the scan types reported by the driver. NULL
+// values are returned as nil.
+func scanRow(rows driver.Rows) (map[string]*string, error) {
+	columnTypes := rows.ColumnTypes()
+	values := make([]any, len(columnTypes))
+	for i, columnType := range columnTypes {
+		values[i] = reflect.New(columnType.ScanType()).Interface()
+	}
+	if err := rows.Scan(values...); err != nil {
+		return nil, err
+	}
+
+	row := make(map[string]*string, len(columnTypes))
+	for i, columnType := range columnTypes {
+		row[columnType.Name()] = formatValue(reflect.ValueOf(values[i]))
+	}
+	return row, nil
+}
+
+// formatValue renders a scanned value the way clickhouse-client prints it,
+// encoding composite types as JSON.
+func formatValue(value reflect.Value) *string {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+
+	var formatted string
+	switch v := value.Interface().(type) {
+	case string:
+		formatted = v
+	case []byte:
+		formatted = string(v)
+	case time.Time:
+		formatted = v.Format("2006-01-02 15:04:05.999999999")
+	case fmt.Stringer:
+		formatted = v.String()
+	default:
+		switch value.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map, reflect.Struct:
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				formatted = fmt.Sprint(v)
+			} else {
+				formatted = string(encoded)
+			}
+		default:
+			formatted = fmt.Sprint(v)
+		}
+	}
+	return &formatted
+}
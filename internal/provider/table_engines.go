@@ -0,0 +1,511 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// tableEngine is implemented by the typed engine blocks of clickhouse_table.
+type tableEngine interface {
+	// engineName returns the engine as reported by system.tables.
+	engineName() string
+	// definition renders the engine expression along with the engine
+	// settings that go into the SETTINGS clause.
+	definition(ctx context.Context, diags *diag.Diagnostics) (string, engineSettings)
+	// validate checks the block before it reaches the server.
+	validate(ctx context.Context, blockPath path.Path, diags *diag.Diagnostics)
+	// requirements returns the server capabilities the engine needs.
+	requirements() []capability
+}
+
+// engineSettings collects the engine settings of a block as SQL literals,
+// skipping unset attributes. String attributes are always quoted, so that a
+// numeric password or topic name is not sent as a number.
+type engineSettings map[string]string
+
+func (s engineSettings) addString(name string, value types.String) {
+	if !value.IsNull() && !value.IsUnknown() {
+		s[name] = quoteString(value.ValueString())
+	}
+}
+
+func (s engineSettings) addInt(name string, value types.Int64) {
+	if !value.IsNull() && !value.IsUnknown() {
+		s[name] = strconv.FormatInt(value.ValueInt64(), 10)
+	}
+}
+
+func (s engineSettings) addList(ctx context.Context, name string, value types.List, diags *diag.Diagnostics) {
+	if values := stringsFromList(ctx, value, diags); len(values) > 0 {
+		s[name] = quoteString(strings.Join(values, ","))
+	}
+}
+
+// engineArguments renders positional engine arguments as string literals.
+func engineArguments(values ...string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, quoteString(value))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// collectionOverride pairs a named collection key with the attribute
+// overriding it.
+type collectionOverride struct {
+	key   string
+	value types.String
+}
+
+// namedCollectionArguments renders a named collection reference followed by
+// key = value overrides, skipping unset values.
+func namedCollectionArguments(collection types.String, overrides []collectionOverride) string {
+	arguments := []string{quoteIdentifier(collection.ValueString())}
+	for _, override := range overrides {
+		if !override.value.IsNull() && !override.value.IsUnknown() {
+			arguments = append(arguments, override.key+" = "+quoteString(override.value.ValueString()))
+		}
+	}
+	return strings.Join(arguments, ", ")
+}
+
+// requireEngineAttributes reports the attributes that must be set when the
+// block does not reference a named collection.
+func requireEngineAttributes(blockPath path.Path, engine string, diags *diag.Diagnostics, attributes map[string]attr.Value) {
+	for name, value := range attributes {
+		if value.IsNull() {
+			diags.AddAttributeError(
+				blockPath.AtName(name),
+				"Missing "+engine+" Engine Argument",
+				"The "+name+" attribute is required unless named_collection is set.",
+			)
+		}
+	}
+}
+
+// validateOneOf reports a value that is not in the allowed list.
+func validateOneOf(attributePath path.Path, summary string, value types.String, allowed []string, diags *diag.Diagnostics) {
+	if value.IsNull() || value.IsUnknown() {
+		return
+	}
+	for _, candidate := range allowed {
+		if value.ValueString() == candidate {
+			return
+		}
+	}
+	diags.AddAttributeError(
+		attributePath,
+		summary,
+		"The value must be one of "+strings.Join(allowed, ", ")+", got: "+value.ValueString(),
+	)
+}
+
+// engineBlock builds a single nested block for an engine. Any change to the
+// block replaces the table since engine arguments cannot be altered.
+func engineBlock(description string, attributes map[string]schema.Attribute) schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: description,
+		Attributes:  attributes,
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+func optionalString(description string) schema.StringAttribute {
+	return schema.StringAttribute{Optional: true, Description: description}
+}
+
+func sensitiveString(description string) schema.StringAttribute {
+	return schema.StringAttribute{Optional: true, Sensitive: true, Description: description}
+}
+
+func optionalInt(description string) schema.Int64Attribute {
+	return schema.Int64Attribute{Optional: true, Description: description}
+}
+
+func optionalStringList(description string) schema.ListAttribute {
+	return schema.ListAttribute{ElementType: types.StringType, Optional: true, Description: description}
+}
+
+// queueModes lists the processing modes of S3Queue and AzureQueue.
+var queueModes = []string{"ordered", "unordered"}
+
+// kafkaEngineModel maps the kafka block.
+type kafkaEngineModel struct {
+	NamedCollection  types.String `tfsdk:"named_collection"`
+	BrokerList       types.List   `tfsdk:"broker_list"`
+	TopicList        types.List   `tfsdk:"topic_list"`
+	GroupName        types.String `tfsdk:"group_name"`
+	Format           types.String `tfsdk:"format"`
+	NumConsumers     types.Int64  `tfsdk:"num_consumers"`
+	SecurityProtocol types.String `tfsdk:"security_protocol"`
+	SaslMechanism    types.String `tfsdk:"sasl_mechanism"`
+	SaslUsername     types.String `tfsdk:"sasl_username"`
+	SaslPassword     types.String `tfsdk:"sasl_password"`
+}
+
+func kafkaEngineBlock() schema.SingleNestedBlock {
+	return engineBlock("Kafka engine consuming from Kafka topics.", map[string]schema.Attribute{
+		"named_collection":  optionalString("A named collection holding the connection settings."),
+		"broker_list":       optionalStringList("Kafka brokers as host:port."),
+		"topic_list":        optionalStringList("Topics to consume."),
+		"group_name":        optionalString("The consumer group name."),
+		"format":            optionalString("The message format, e.g. JSONEachRow."),
+		"num_consumers":     optionalInt("The number of consumers per table."),
+		"security_protocol": optionalString("The protocol used to talk to brokers, e.g. SASL_SSL."),
+		"sasl_mechanism":    optionalString("The SASL mechanism, e.g. SCRAM-SHA-512."),
+		"sasl_username":     sensitiveString("The SASL username."),
+		"sasl_password":     sensitiveString("The SASL password."),
+	})
+}
+
+func (m *kafkaEngineModel) engineName() string { return "Kafka" }
+
+func (m *kafkaEngineModel) requirements() []capability { return []capability{capKafkaEngine} }
+
+func (m *kafkaEngineModel) definition(ctx context.Context, diags *diag.Diagnostics) (string, engineSettings) {
+	settings := engineSettings{}
+	settings.addList(ctx, "kafka_broker_list", m.BrokerList, diags)
+	settings.addList(ctx, "kafka_topic_list", m.TopicList, diags)
+	settings.addString("kafka_group_name", m.GroupName)
+	settings.addString("kafka_format", m.Format)
+	settings.addInt("kafka_num_consumers", m.NumConsumers)
+	settings.addString("kafka_security_protocol", m.SecurityProtocol)
+	settings.addString("kafka_sasl_mechanism", m.SaslMechanism)
+	settings.addString("kafka_sasl_username", m.SaslUsername)
+	settings.addString("kafka_sasl_password", m.SaslPassword)
+
+	if !m.NamedCollection.IsNull() {
+		return "Kafka(" + quoteIdentifier(m.NamedCollection.ValueString()) + ")", settings
+	}
+	return "Kafka", settings
+}
+
+func (m *kafkaEngineModel) validate(_ context.Context, blockPath path.Path, diags *diag.Diagnostics) {
+	if m.NamedCollection.IsNull() {
+		requireEngineAttributes(blockPath, "Kafka", diags, map[string]attr.Value{
+			"broker_list": m.BrokerList,
+			"topic_list":  m.TopicList,
+			"group_name":  m.GroupName,
+			"format":      m.Format,
+		})
+	}
+	if !m.SaslPassword.IsNull() && m.SaslUsername.IsNull() && m.NamedCollection.IsNull() {
+		diags.AddAttributeError(
+			blockPath.AtName("sasl_username"),
+			"Missing Kafka Engine Argument",
+			"The sasl_username attribute is required when sasl_password is set.",
+		)
+	}
+}
+
+// rabbitmqEngineModel maps the rabbitmq block.
+type rabbitmqEngineModel struct {
+	NamedCollection types.String `tfsdk:"named_collection"`
+	HostPort        types.String `tfsdk:"host_port"`
+	ExchangeName    types.String `tfsdk:"exchange_name"`
+	ExchangeType    types.String `tfsdk:"exchange_type"`
+	RoutingKeyList  types.List   `tfsdk:"routing_key_list"`
+	Format          types.String `tfsdk:"format"`
+	NumConsumers    types.Int64  `tfsdk:"num_consumers"`
+	QueueBase       types.String `tfsdk:"queue_base"`
+	Vhost           types.String `tfsdk:"vhost"`
+	Username        types.String `tfsdk:"username"`
+	Password        types.String `tfsdk:"password"`
+}
+
+func rabbitmqEngineBlock() schema.SingleNestedBlock {
+	return engineBlock("RabbitMQ engine consuming from a RabbitMQ exchange.", map[string]schema.Attribute{
+		"named_collection": optionalString("A named collection holding the connection settings."),
+		"host_port":        optionalString("The RabbitMQ server as host:port."),
+		"exchange_name":    optionalString("The exchange to bind to."),
+		"exchange_type":    optionalString("The exchange type, e.g. direct, fanout or topic."),
+		"routing_key_list": optionalStringList("Routing keys to bind with."),
+		"format":           optionalString("The message format, e.g. JSONEachRow."),
+		"num_consumers":    optionalInt("The number of consumers per table."),
+		"queue_base":       optionalString("A prefix for the queue names."),
+		"vhost":            optionalString("The RabbitMQ virtual host."),
+		"username":         optionalString("The RabbitMQ username."),
+		"password":         sensitiveString("The RabbitMQ password."),
+	})
+}
+
+func (m *rabbitmqEngineModel) engineName() string { return "RabbitMQ" }
+
+func (m *rabbitmqEngineModel) requirements() []capability { return []capability{capRabbitMQEngine} }
+
+func (m *rabbitmqEngineModel) definition(ctx context.Context, diags *diag.Diagnostics) (string, engineSettings) {
+	settings := engineSettings{}
+	settings.addString("rabbitmq_host_port", m.HostPort)
+	settings.addString("rabbitmq_exchange_name", m.ExchangeName)
+	settings.addString("rabbitmq_exchange_type", m.ExchangeType)
+	settings.addList(ctx, "rabbitmq_routing_key_list", m.RoutingKeyList, diags)
+	settings.addString("rabbitmq_format", m.Format)
+	settings.addInt("rabbitmq_num_consumers", m.NumConsumers)
+	settings.addString("rabbitmq_queue_base", m.QueueBase)
+	settings.addString("rabbitmq_vhost", m.Vhost)
+	settings.addString("rabbitmq_username", m.Username)
+	settings.addString("rabbitmq_password", m.Password)
+
+	if !m.NamedCollection.IsNull() {
+		return "RabbitMQ(" + quoteIdentifier(m.NamedCollection.ValueString()) + ")", settings
+	}
+	return "RabbitMQ", settings
+}
+
+func (m *rabbitmqEngineModel) validate(_ context.Context, blockPath path.Path, diags *diag.Diagnostics) {
+	if m.NamedCollection.IsNull() {
+		requireEngineAttributes(blockPath, "RabbitMQ", diags, map[string]attr.Value{
+			"host_port":     m.HostPort,
+			"exchange_name": m.ExchangeName,
+			"format":        m.Format,
+		})
+	}
+}
+
+// natsEngineModel maps the nats block.
+type natsEngineModel struct {
+	NamedCollection types.String `tfsdk:"named_collection"`
+	URL             types.String `tfsdk:"url"`
+	Subjects        types.List   `tfsdk:"subjects"`
+	Format          types.String `tfsdk:"format"`
+	QueueGroup      types.String `tfsdk:"queue_group"`
+	NumConsumers    types.Int64  `tfsdk:"num_consumers"`
+	Username        types.String `tfsdk:"username"`
+	Password        types.String `tfsdk:"password"`
+	Token           types.String `tfsdk:"token"`
+}
+
+func natsEngineBlock() schema.SingleNestedBlock {
+	return engineBlock("NATS engine consuming from NATS subjects.", map[string]schema.Attribute{
+		"named_collection": optionalString("A named collection holding the connection settings."),
+		"url":              optionalString("The NATS server as host:port."),
+		"subjects":         optionalStringList("Subjects to subscribe to."),
+		"format":           optionalString("The message format, e.g. JSONEachRow."),
+		"queue_group":      optionalString("The queue group of the subscribers."),
+		"num_consumers":    optionalInt("The number of consumers per table."),
+		"username":         optionalString("The NATS username."),
+		"password":         sensitiveString("The NATS password."),
+		"token":            sensitiveString("The NATS authentication token."),
+	})
+}
+
+func (m *natsEngineModel) engineName() string { return "NATS" }
+
+func (m *natsEngineModel) requirements() []capability { return []capability{capNATSEngine} }
+
+func (m *natsEngineModel) definition(ctx context.Context, diags *diag.Diagnostics) (string, engineSettings) {
+	settings := engineSettings{}
+	settings.addString("nats_url", m.URL)
+	settings.addList(ctx, "nats_subjects", m.Subjects, diags)
+	settings.addString("nats_format", m.Format)
+	settings.addString("nats_queue_group", m.QueueGroup)
+	settings.addInt("nats_num_consumers", m.NumConsumers)
+	settings.addString("nats_username", m.Username)
+	settings.addString("nats_password", m.Password)
+	settings.addString("nats_token", m.Token)
+
+	if !m.NamedCollection.IsNull() {
+		return "NATS(" + quoteIdentifier(m.NamedCollection.ValueString()) + ")", settings
+	}
+	return "NATS", settings
+}
+
+func (m *natsEngineModel) validate(_ context.Context, blockPath path.Path, diags *diag.Diagnostics) {
+	if m.NamedCollection.IsNull() {
+		requireEngineAttributes(blockPath, "NATS", diags, map[string]attr.Value{
+			"url":      m.URL,
+			"subjects": m.Subjects,
+			"format":   m.Format,
+		})
+	}
+	if !m.Token.IsNull() && !m.Password.IsNull() {
+		diags.AddAttributeError(
+			blockPath.AtName("token"),
+			"Conflicting NATS Engine Arguments",
+			"Only one of token and password can be set.",
+		)
+	}
+}
+
+// s3QueueEngineModel maps the s3queue block.
+type s3QueueEngineModel struct {
+	NamedCollection types.String `tfsdk:"named_collection"`
+	URL             types.String `tfsdk:"url"`
+	Format          types.String `tfsdk:"format"`
+	Compression     types.String `tfsdk:"compression"`
+	AccessKeyID     types.String `tfsdk:"access_key_id"`
+	SecretAccessKey types.String `tfsdk:"secret_access_key"`
+	Mode            types.String `tfsdk:"mode"`
+	KeeperPath      types.String `tfsdk:"keeper_path"`
+}
+
+func s3QueueEngineBlock() schema.SingleNestedBlock {
+	return engineBlock("S3Queue engine streaming files from an S3 bucket.", map[string]schema.Attribute{
+		"named_collection":  optionalString("A named collection holding the connection settings."),
+		"url":               optionalString("The bucket URL with a path glob, e.g. https://bucket.s3.amazonaws.com/data/*.json."),
+		"format":            optionalString("The file format, e.g. JSONEachRow."),
+		"compression":       optionalString("The compression of the files. Detected from the extension when unset."),
+		"access_key_id":     optionalString("The AWS access key ID."),
+		"secret_access_key": sensitiveString("The AWS secret access key."),
+		"mode":              optionalString("How processed files are tracked: ordered or unordered."),
+		"keeper_path":       optionalString("The Keeper path used to track processed files."),
+	})
+}
+
+func (m *s3QueueEngineModel) engineName() string { return "S3Queue" }
+
+func (m *s3QueueEngineModel) requirements() []capability { return []capability{capS3QueueEngine} }
+
+func (m *s3QueueEngineModel) definition(_ context.Context, _ *diag.Diagnostics) (string, engineSettings) {
+	settings := engineSettings{}
+	settings.addString("mode", m.Mode)
+	settings.addString("keeper_path", m.KeeperPath)
+
+	if !m.NamedCollection.IsNull() {
+		return "S3Queue(" + namedCollectionArguments(m.NamedCollection, []collectionOverride{
+			{"url", m.URL},
+			{"format", m.Format},
+			{"compression", m.Compression},
+			{"access_key_id", m.AccessKeyID},
+			{"secret_access_key", m.SecretAccessKey},
+		}) + ")", settings
+	}
+
+	arguments := []string{m.URL.ValueString()}
+	if !m.AccessKeyID.IsNull() {
+		arguments = append(arguments, m.AccessKeyID.ValueString(), m.SecretAccessKey.ValueString())
+	}
+	arguments = append(arguments, m.Format.ValueString())
+	if !m.Compression.IsNull() {
+		arguments = append(arguments, m.Compression.ValueString())
+	}
+	return "S3Queue(" + engineArguments(arguments...) + ")", settings
+}
+
+func (m *s3QueueEngineModel) validate(_ context.Context, blockPath path.Path, diags *diag.Diagnostics) {
+	if m.NamedCollection.IsNull() {
+		requireEngineAttributes(blockPath, "S3Queue", diags, map[string]attr.Value{
+			"url":    m.URL,
+			"format": m.Format,
+		})
+	}
+	if m.AccessKeyID.IsNull() != m.SecretAccessKey.IsNull() {
+		diags.AddAttributeError(
+			blockPath.AtName("secret_access_key"),
+			"Incomplete S3Queue Credentials",
+			"The access_key_id and secret_access_key attributes must be set together.",
+		)
+	}
+	validateOneOf(blockPath.AtName("mode"), "Invalid S3Queue Mode", m.Mode, queueModes, diags)
+}
+
+// azureQueueEngineModel maps the azurequeue block.
+type azureQueueEngineModel struct {
+	NamedCollection   types.String `tfsdk:"named_collection"`
+	ConnectionString  types.String `tfsdk:"connection_string"`
+	StorageAccountURL types.String `tfsdk:"storage_account_url"`
+	Container         types.String `tfsdk:"container"`
+	BlobPath          types.String `tfsdk:"blob_path"`
+	AccountName       types.String `tfsdk:"account_name"`
+	AccountKey        types.String `tfsdk:"account_key"`
+	Format            types.String `tfsdk:"format"`
+	Compression       types.String `tfsdk:"compression"`
+	Mode              types.String `tfsdk:"mode"`
+	KeeperPath        types.String `tfsdk:"keeper_path"`
+}
+
+func azureQueueEngineBlock() schema.SingleNestedBlock {
+	return engineBlock("AzureQueue engine streaming blobs from an Azure container.", map[string]schema.Attribute{
+		"named_collection":    optionalString("A named collection holding the connection settings."),
+		"connection_string":   sensitiveString("The Azure storage connection string."),
+		"storage_account_url": optionalString("The storage account URL, used with account_name and account_key."),
+		"container":           optionalString("The blob container."),
+		"blob_path":           optionalString("The blob path with an optional glob."),
+		"account_name":        optionalString("The storage account name."),
+		"account_key":         sensitiveString("The storage account key."),
+		"format":              optionalString("The file format, e.g. JSONEachRow."),
+		"compression":         optionalString("The compression of the blobs. Detected from the extension when unset."),
+		"mode":                optionalString("How processed blobs are tracked: ordered or unordered."),
+		"keeper_path":         optionalString("The Keeper path used to track processed blobs."),
+	})
+}
+
+func (m *azureQueueEngineModel) engineName() string { return "AzureQueue" }
+
+func (m *azureQueueEngineModel) requirements() []capability { return []capability{capAzureQueueEngine} }
+
+func (m *azureQueueEngineModel) definition(_ context.Context, _ *diag.Diagnostics) (string, engineSettings) {
+	settings := engineSettings{}
+	settings.addString("mode", m.Mode)
+	settings.addString("keeper_path", m.KeeperPath)
+
+	if !m.NamedCollection.IsNull() {
+		return "AzureQueue(" + namedCollectionArguments(m.NamedCollection, []collectionOverride{
+			{"connection_string", m.ConnectionString},
+			{"storage_account_url", m.StorageAccountURL},
+			{"container", m.Container},
+			{"blob_path", m.BlobPath},
+			{"account_name", m.AccountName},
+			{"account_key", m.AccountKey},
+			{"format", m.Format},
+			{"compression", m.Compression},
+		}) + ")", settings
+	}
+
+	var arguments []string
+	if !m.ConnectionString.IsNull() {
+		arguments = []string{m.ConnectionString.ValueString(), m.Container.ValueString(), m.BlobPath.ValueString(), m.Format.ValueString()}
+	} else {
+		arguments = []string{
+			m.StorageAccountURL.ValueString(), m.Container.ValueString(), m.BlobPath.ValueString(),
+			m.AccountName.ValueString(), m.AccountKey.ValueString(), m.Format.ValueString(),
+		}
+	}
+	if !m.Compression.IsNull() {
+		arguments = append(arguments, m.Compression.ValueString())
+	}
+	return "AzureQueue(" + engineArguments(arguments...) + ")", settings
+}
+
+func (m *azureQueueEngineModel) validate(_ context.Context, blockPath path.Path, diags *diag.Diagnostics) {
+	validateOneOf(blockPath.AtName("mode"), "Invalid AzureQueue Mode", m.Mode, queueModes, diags)
+	if !m.NamedCollection.IsNull() {
+		return
+	}
+
+	requireEngineAttributes(blockPath, "AzureQueue", diags, map[string]attr.Value{
+		"container": m.Container,
+		"blob_path": m.BlobPath,
+		"format":    m.Format,
+	})
+	switch {
+	case !m.ConnectionString.IsNull() && !m.StorageAccountURL.IsNull():
+		diags.AddAttributeError(
+			blockPath.AtName("storage_account_url"),
+			"Conflicting AzureQueue Engine Arguments",
+			"Only one of connection_string and storage_account_url can be set.",
+		)
+	case m.ConnectionString.IsNull() && m.StorageAccountURL.IsNull():
+		diags.AddAttributeError(
+			blockPath.AtName("connection_string"),
+			"Missing AzureQueue Engine Argument",
+			"One of connection_string or storage_account_url is required unless named_collection is set.",
+		)
+	case !m.StorageAccountURL.IsNull():
+		requireEngineAttributes(blockPath, "AzureQueue", diags, map[string]attr.Value{
+			"account_name": m.AccountName,
+			"account_key":  m.AccountKey,
+		})
+	}
+}
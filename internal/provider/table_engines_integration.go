@@ -0,0 +1,517 @@
+package provider
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// formatNamePattern matches ClickHouse input and output format names.
+var formatNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// validateHostPort reports a value that is not written as host:port.
+func validateHostPort(attributePath path.Path, engine string, value types.String, diags *diag.Diagnostics) {
+	if value.IsNull() || value.IsUnknown() {
+		return
+	}
+	host, port, err := net.SplitHostPort(value.ValueString())
+	if err == nil && host != "" {
+		if _, err = strconv.ParseUint(port, 10, 16); err == nil {
+			return
+		}
+	}
+	diags.AddAttributeError(
+		attributePath,
+		"Invalid "+engine+" Engine Address",
+		"The address must be written as host:port, got: "+value.ValueString(),
+	)
+}
+
+// validateHTTPURL reports a value that is not an absolute http or https URL.
+func validateHTTPURL(attributePath path.Path, engine string, value types.String, diags *diag.Diagnostics) {
+	if value.IsNull() || value.IsUnknown() {
+		return
+	}
+	parsed, err := url.Parse(value.ValueString())
+	if err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != "" {
+		return
+	}
+	diags.AddAttributeError(
+		attributePath,
+		"Invalid "+engine+" Engine URL",
+		"The URL must be an absolute http or https URL, got: "+value.ValueString(),
+	)
+}
+
+// validateFormatName reports a value that is not a format name.
+func validateFormatName(attributePath path.Path, engine string, value types.String, diags *diag.Diagnostics) {
+	if value.IsNull() || value.IsUnknown() || formatNamePattern.MatchString(value.ValueString()) {
+		return
+	}
+	diags.AddAttributeError(
+		attributePath,
+		"Invalid "+engine+" Engine Format",
+		"The format must be a ClickHouse format name such as Parquet or JSONEachRow, got: "+value.ValueString(),
+	)
+}
+
+// requireTogether reports a dependent attribute that is set without the
+// attribute it relies on.
+func requireTogether(blockPath path.Path, engine, dependent string, dependentValue attr.Value, required string, requiredValue attr.Value, diags *diag.Diagnostics) {
+	if !dependentValue.IsNull() && requiredValue.IsNull() {
+		diags.AddAttributeError(
+			blockPath.AtName(dependent),
+			"Missing "+engine+" Engine Argument",
+			"The "+required+" attribute is required when "+dependent+" is set.",
+		)
+	}
+}
+
+// s3EngineModel maps the s3 block.
+type s3EngineModel struct {
+	NamedCollection types.String `tfsdk:"named_collection"`
+	URL             types.String `tfsdk:"url"`
+	NoSign          types.Bool   `tfsdk:"no_sign"`
+	AccessKeyID     types.String `tfsdk:"access_key_id"`
+	SecretAccessKey types.String `tfsdk:"secret_access_key"`
+	SessionToken    types.String `tfsdk:"session_token"`
+	Format          types.String `tfsdk:"format"`
+	Compression     types.String `tfsdk:"compression"`
+}
+
+func s3EngineBlock() schema.SingleNestedBlock {
+	return engineBlock("S3 engine reading and writing objects in an S3 bucket.", map[string]schema.Attribute{
+		"named_collection":  optionalString("A named collection holding the connection settings."),
+		"url":               optionalString("The bucket URL with an optional path glob."),
+		"no_sign":           schema.BoolAttribute{Optional: true, Description: "Send unsigned requests, for public buckets."},
+		"access_key_id":     optionalString("The AWS access key ID."),
+		"secret_access_key": sensitiveString("The AWS secret access key."),
+		"session_token":     sensitiveString("The AWS session token for temporary credentials."),
+		"format":            optionalString("The file format, e.g. Parquet."),
+		"compression":       optionalString("The compression of the objects. Detected from the extension when unset."),
+	})
+}
+
+func (m *s3EngineModel) engineName() string { return "S3" }
+
+func (m *s3EngineModel) requirements() []capability { return []capability{capS3Engine} }
+
+func (m *s3EngineModel) definition(_ context.Context, _ *diag.Diagnostics) (string, engineSettings) {
+	if !m.NamedCollection.IsNull() {
+		return "S3(" + namedCollectionArguments(m.NamedCollection, []collectionOverride{
+			{"url", m.URL},
+			{"access_key_id", m.AccessKeyID},
+			{"secret_access_key", m.SecretAccessKey},
+			{"session_token", m.SessionToken},
+			{"format", m.Format},
+			{"compression", m.Compression},
+		}) + ")", nil
+	}
+
+	arguments := engineArguments(m.URL.ValueString())
+	switch {
+	case m.NoSign.ValueBool():
+		arguments += ", NOSIGN"
+	case !m.AccessKeyID.IsNull():
+		arguments += ", " + engineArguments(m.AccessKeyID.ValueString(), m.SecretAccessKey.ValueString())
+		if !m.SessionToken.IsNull() {
+			arguments += ", " + engineArguments(m.SessionToken.ValueString())
+		}
+	}
+	if !m.Format.IsNull() {
+		arguments += ", " + engineArguments(m.Format.ValueString())
+		if !m.Compression.IsNull() {
+			arguments += ", " + engineArguments(m.Compression.ValueString())
+		}
+	}
+	return "S3(" + arguments + ")", nil
+}
+
+func (m *s3EngineModel) validate(_ context.Context, blockPath path.Path, diags *diag.Diagnostics) {
+	validateHTTPURL(blockPath.AtName("url"), "S3", m.URL, diags)
+	validateFormatName(blockPath.AtName("format"), "S3", m.Format, diags)
+	if m.AccessKeyID.IsNull() != m.SecretAccessKey.IsNull() {
+		diags.AddAttributeError(
+			blockPath.AtName("secret_access_key"),
+			"Incomplete S3 Credentials",
+			"The access_key_id and secret_access_key attributes must be set together.",
+		)
+	}
+	requireTogether(blockPath, "S3", "session_token", m.SessionToken, "access_key_id", m.AccessKeyID, diags)
+	if m.NoSign.ValueBool() && !m.AccessKeyID.IsNull() {
+		diags.AddAttributeError(
+			blockPath.AtName("no_sign"),
+			"Conflicting S3 Engine Arguments",
+			"Credentials cannot be set when no_sign is enabled.",
+		)
+	}
+	if m.NamedCollection.IsNull() {
+		requireEngineAttributes(blockPath, "S3", diags, map[string]attr.Value{"url": m.URL})
+		requireTogether(blockPath, "S3", "compression", m.Compression, "format", m.Format, diags)
+	}
+}
+
+// urlEngineModel maps the url block.
+type urlEngineModel struct {
+	NamedCollection types.String `tfsdk:"named_collection"`
+	URL             types.String `tfsdk:"url"`
+	Format          types.String `tfsdk:"format"`
+	Compression     types.String `tfsdk:"compression"`
+}
+
+func urlEngineBlock() schema.SingleNestedBlock {
+	return engineBlock("URL engine reading and writing data over HTTP.", map[string]schema.Attribute{
+		"named_collection": optionalString("A named collection holding the connection settings."),
+		"url":              optionalString("The HTTP or HTTPS URL."),
+		"format":           optionalString("The data format, e.g. CSV."),
+		"compression":      optionalString("The compression of the data."),
+	})
+}
+
+func (m *urlEngineModel) engineName() string { return "URL" }
+
+func (m *urlEngineModel) requirements() []capability { return nil }
+
+func (m *urlEngineModel) definition(_ context.Context, _ *diag.Diagnostics) (string, engineSettings) {
+	if !m.NamedCollection.IsNull() {
+		return "URL(" + namedCollectionArguments(m.NamedCollection, []collectionOverride{
+			{"url", m.URL},
+			{"format", m.Format},
+			{"compression_method", m.Compression},
+		}) + ")", nil
+	}
+
+	arguments := []string{m.URL.ValueString(), m.Format.ValueString()}
+	if !m.Compression.IsNull() {
+		arguments = append(arguments, m.Compression.ValueString())
+	}
+	return "URL(" + engineArguments(arguments...) + ")", nil
+}
+
+func (m *urlEngineModel) validate(_ context.Context, blockPath path.Path, diags *diag.Diagnostics) {
+	validateHTTPURL(blockPath.AtName("url"), "URL", m.URL, diags)
+	validateFormatName(blockPath.AtName("format"), "URL", m.Format, diags)
+	if m.NamedCollection.IsNull() {
+		requireEngineAttributes(blockPath, "URL", diags, map[string]attr.Value{
+			"url":    m.URL,
+			"format": m.Format,
+		})
+	}
+}
+
+// fileEngineModel maps the file block.
+type fileEngineModel struct {
+	Format      types.String `tfsdk:"format"`
+	Compression types.String `tfsdk:"compression"`
+}
+
+func fileEngineBlock() schema.SingleNestedBlock {
+	return engineBlock("File engine storing data in a file in the table's data directory.", map[string]schema.Attribute{
+		"format":      optionalString("The file format, e.g. TabSeparated."),
+		"compression": optionalString("The compression of the file."),
+	})
+}
+
+func (m *fileEngineModel) engineName() string { return "File" }
+
+func (m *fileEngineModel) requirements() []capability { return nil }
+
+func (m *fileEngineModel) definition(_ context.Context, _ *diag.Diagnostics) (string, engineSettings) {
+	arguments := m.Format.ValueString()
+	if !m.Compression.IsNull() {
+		arguments += ", " + engineArguments(m.Compression.ValueString())
+	}
+	return "File(" + arguments + ")", nil
+}
+
+func (m *fileEngineModel) validate(_ context.Context, blockPath path.Path, diags *diag.Diagnostics) {
+	if m.Format.IsNull() {
+		diags.AddAttributeError(
+			blockPath.AtName("format"),
+			"Missing File Engine Argument",
+			"The format attribute is required.",
+		)
+	}
+	validateFormatName(blockPath.AtName("format"), "File", m.Format, diags)
+}
+
+// mysqlEngineModel maps the mysql block.
+type mysqlEngineModel struct {
+	NamedCollection   types.String `tfsdk:"named_collection"`
+	HostPort          types.String `tfsdk:"host_port"`
+	Database          types.String `tfsdk:"database"`
+	Table             types.String `tfsdk:"table"`
+	User              types.String `tfsdk:"user"`
+	Password          types.String `tfsdk:"password"`
+	ReplaceQuery      types.Bool   `tfsdk:"replace_query"`
+	OnDuplicateClause types.String `tfsdk:"on_duplicate_clause"`
+}
+
+func mysqlEngineBlock() schema.SingleNestedBlock {
+	return engineBlock("MySQL engine querying a table on a remote MySQL server.", map[string]schema.Attribute{
+		"named_collection":    optionalString("A named collection holding the connection settings."),
+		"host_port":           optionalString("The MySQL server as host:port."),
+		"database":            optionalString("The remote database."),
+		"table":               optionalString("The remote table."),
+		"user":                optionalString("The MySQL user."),
+		"password":            sensitiveString("The MySQL password."),
+		"replace_query":       schema.BoolAttribute{Optional: true, Description: "Use REPLACE INTO instead of INSERT INTO."},
+		"on_duplicate_clause": optionalString("The ON DUPLICATE KEY clause added to inserts."),
+	})
+}
+
+func (m *mysqlEngineModel) engineName() string { return "MySQL" }
+
+func (m *mysqlEngineModel) requirements() []capability { return []capability{capMySQLEngine} }
+
+func (m *mysqlEngineModel) definition(_ context.Context, _ *diag.Diagnostics) (string, engineSettings) {
+	if !m.NamedCollection.IsNull() {
+		overrides := []collectionOverride{
+			{"database", m.Database},
+			{"table", m.Table},
+			{"user", m.User},
+			{"password", m.Password},
+		}
+		if host, port, err := net.SplitHostPort(m.HostPort.ValueString()); err == nil {
+			overrides = append(overrides, collectionOverride{"host", types.StringValue(host)}, collectionOverride{"port", types.StringValue(port)})
+		}
+		return "MySQL(" + namedCollectionArguments(m.NamedCollection, overrides) + ")", nil
+	}
+
+	arguments := engineArguments(m.HostPort.ValueString(), m.Database.ValueString(), m.Table.ValueString(), m.User.ValueString(), m.Password.ValueString())
+	if !m.ReplaceQuery.IsNull() || !m.OnDuplicateClause.IsNull() {
+		replaceQuery := "0"
+		if m.ReplaceQuery.ValueBool() {
+			replaceQuery = "1"
+		}
+		arguments += ", " + replaceQuery
+		if !m.OnDuplicateClause.IsNull() {
+			arguments += ", " + engineArguments(m.OnDuplicateClause.ValueString())
+		}
+	}
+	return "MySQL(" + arguments + ")", nil
+}
+
+func (m *mysqlEngineModel) validate(_ context.Context, blockPath path.Path, diags *diag.Diagnostics) {
+	validateHostPort(blockPath.AtName("host_port"), "MySQL", m.HostPort, diags)
+	if m.ReplaceQuery.ValueBool() && !m.OnDuplicateClause.IsNull() {
+		diags.AddAttributeError(
+			blockPath.AtName("on_duplicate_clause"),
+			"Conflicting MySQL Engine Arguments",
+			"The on_duplicate_clause attribute cannot be used together with replace_query.",
+		)
+	}
+	if m.NamedCollection.IsNull() {
+		requireEngineAttributes(blockPath, "MySQL", diags, map[string]attr.Value{
+			"host_port": m.HostPort,
+			"database":  m.Database,
+			"table":     m.Table,
+			"user":      m.User,
+			"password":  m.Password,
+		})
+	}
+}
+
+// postgresqlEngineModel maps the postgresql block.
+type postgresqlEngineModel struct {
+	NamedCollection types.String `tfsdk:"named_collection"`
+	HostPort        types.String `tfsdk:"host_port"`
+	Database        types.String `tfsdk:"database"`
+	Table           types.String `tfsdk:"table"`
+	User            types.String `tfsdk:"user"`
+	Password        types.String `tfsdk:"password"`
+	Schema          types.String `tfsdk:"schema"`
+	OnConflict      types.String `tfsdk:"on_conflict"`
+}
+
+func postgresqlEngineBlock() schema.SingleNestedBlock {
+	return engineBlock("PostgreSQL engine querying a table on a remote PostgreSQL server.", map[string]schema.Attribute{
+		"named_collection": optionalString("A named collection holding the connection settings."),
+		"host_port":        optionalString("The PostgreSQL server as host:port."),
+		"database":         optionalString("The remote database."),
+		"table":            optionalString("The remote table."),
+		"user":             optionalString("The PostgreSQL user."),
+		"password":         sensitiveString("The PostgreSQL password."),
+		"schema":           optionalString("The remote schema. Defaults to the search path of the user."),
+		"on_conflict":      optionalString("The ON CONFLICT clause added to inserts, e.g. ON CONFLICT DO NOTHING."),
+	})
+}
+
+func (m *postgresqlEngineModel) engineName() string { return "PostgreSQL" }
+
+func (m *postgresqlEngineModel) requirements() []capability { return []capability{capPostgreSQLEngine} }
+
+func (m *postgresqlEngineModel) definition(_ context.Context, _ *diag.Diagnostics) (string, engineSettings) {
+	if !m.NamedCollection.IsNull() {
+		overrides := []collectionOverride{
+			{"database", m.Database},
+			{"table", m.Table},
+			{"user", m.User},
+			{"password", m.Password},
+			{"schema", m.Schema},
+			{"on_conflict", m.OnConflict},
+		}
+		if host, port, err := net.SplitHostPort(m.HostPort.ValueString()); err == nil {
+			overrides = append(overrides, collectionOverride{"host", types.StringValue(host)}, collectionOverride{"port", types.StringValue(port)})
+		}
+		return "PostgreSQL(" + namedCollectionArguments(m.NamedCollection, overrides) + ")", nil
+	}
+
+	arguments := []string{m.HostPort.ValueString(), m.Database.ValueString(), m.Table.ValueString(), m.User.ValueString(), m.Password.ValueString()}
+	if !m.Schema.IsNull() {
+		arguments = append(arguments, m.Schema.ValueString())
+		if !m.OnConflict.IsNull() {
+			arguments = append(arguments, m.OnConflict.ValueString())
+		}
+	}
+	return "PostgreSQL(" + engineArguments(arguments...) + ")", nil
+}
+
+func (m *postgresqlEngineModel) validate(_ context.Context, blockPath path.Path, diags *diag.Diagnostics) {
+	validateHostPort(blockPath.AtName("host_port"), "PostgreSQL", m.HostPort, diags)
+	if m.NamedCollection.IsNull() {
+		requireEngineAttributes(blockPath, "PostgreSQL", diags, map[string]attr.Value{
+			"host_port": m.HostPort,
+			"database":  m.Database,
+			"table":     m.Table,
+			"user":      m.User,
+			"password":  m.Password,
+		})
+		requireTogether(blockPath, "PostgreSQL", "on_conflict", m.OnConflict, "schema", m.Schema, diags)
+	}
+}
+
+// mongodbEngineModel maps the mongodb block.
+type mongodbEngineModel struct {
+	NamedCollection types.String `tfsdk:"named_collection"`
+	URI             types.String `tfsdk:"uri"`
+	HostPort        types.String `tfsdk:"host_port"`
+	Database        types.String `tfsdk:"database"`
+	Collection      types.String `tfsdk:"collection"`
+	User            types.String `tfsdk:"user"`
+	Password        types.String `tfsdk:"password"`
+	Options         types.String `tfsdk:"options"`
+}
+
+func mongodbEngineBlock() schema.SingleNestedBlock {
+	return engineBlock("MongoDB engine querying a remote MongoDB collection.", map[string]schema.Attribute{
+		"named_collection": optionalString("A named collection holding the connection settings."),
+		"uri":              sensitiveString("A mongodb:// connection URI including the database, used instead of host_port, database, user and password."),
+		"host_port":        optionalString("The MongoDB server as host:port."),
+		"database":         optionalString("The remote database."),
+		"collection":       optionalString("The remote collection."),
+		"user":             optionalString("The MongoDB user."),
+		"password":         sensitiveString("The MongoDB password."),
+		"options":          optionalString("Connection string options, e.g. connectionTimeoutMS=10000."),
+	})
+}
+
+func (m *mongodbEngineModel) engineName() string { return "MongoDB" }
+
+func (m *mongodbEngineModel) requirements() []capability {
+	if !m.URI.IsNull() {
+		return []capability{capMongoDBEngine, capMongoDBURI}
+	}
+	return []capability{capMongoDBEngine}
+}
+
+func (m *mongodbEngineModel) definition(_ context.Context, _ *diag.Diagnostics) (string, engineSettings) {
+	if !m.NamedCollection.IsNull() {
+		overrides := []collectionOverride{
+			{"uri", m.URI},
+			{"database", m.Database},
+			{"collection", m.Collection},
+			{"user", m.User},
+			{"password", m.Password},
+			{"options", m.Options},
+		}
+		if host, port, err := net.SplitHostPort(m.HostPort.ValueString()); err == nil {
+			overrides = append(overrides, collectionOverride{"host", types.StringValue(host)}, collectionOverride{"port", types.StringValue(port)})
+		}
+		return "MongoDB(" + namedCollectionArguments(m.NamedCollection, overrides) + ")", nil
+	}
+
+	if !m.URI.IsNull() {
+		return "MongoDB(" + engineArguments(m.URI.ValueString(), m.Collection.ValueString()) + ")", nil
+	}
+
+	arguments := []string{m.HostPort.ValueString(), m.Database.ValueString(), m.Collection.ValueString(), m.User.ValueString(), m.Password.ValueString()}
+	if !m.Options.IsNull() {
+		arguments = append(arguments, m.Options.ValueString())
+	}
+	return "MongoDB(" + engineArguments(arguments...) + ")", nil
+}
+
+func (m *mongodbEngineModel) validate(_ context.Context, blockPath path.Path, diags *diag.Diagnostics) {
+	validateHostPort(blockPath.AtName("host_port"), "MongoDB", m.HostPort, diags)
+	if !m.URI.IsNull() && !m.URI.IsUnknown() {
+		if parsed, err := url.Parse(m.URI.ValueString()); err != nil || (parsed.Scheme != "mongodb" && parsed.Scheme != "mongodb+srv") {
+			diags.AddAttributeError(
+				blockPath.AtName("uri"),
+				"Invalid MongoDB Engine URI",
+				"The URI must use the mongodb:// or mongodb+srv:// scheme.",
+			)
+		}
+		if !m.HostPort.IsNull() || !m.User.IsNull() || !m.Password.IsNull() {
+			diags.AddAttributeError(
+				blockPath.AtName("uri"),
+				"Conflicting MongoDB Engine Arguments",
+				"The uri attribute cannot be combined with host_port, user or password.",
+			)
+		}
+	}
+	if !m.NamedCollection.IsNull() {
+		return
+	}
+	if m.URI.IsNull() {
+		requireEngineAttributes(blockPath, "MongoDB", diags, map[string]attr.Value{
+			"host_port":  m.HostPort,
+			"database":   m.Database,
+			"collection": m.Collection,
+			"user":       m.User,
+			"password":   m.Password,
+		})
+	} else {
+		requireEngineAttributes(blockPath, "MongoDB", diags, map[string]attr.Value{
+			"collection": m.Collection,
+		})
+	}
+}
+
+// jdbcEngineModel maps the jdbc block.
+type jdbcEngineModel struct {
+	DatasourceURI    types.String `tfsdk:"datasource_uri"`
+	ExternalDatabase types.String `tfsdk:"external_database"`
+	ExternalTable    types.String `tfsdk:"external_table"`
+}
+
+func jdbcEngineBlock() schema.SingleNestedBlock {
+	return engineBlock("JDBC engine querying an external database through clickhouse-jdbc-bridge.", map[string]schema.Attribute{
+		"datasource_uri":    sensitiveString("The JDBC URI or bridge datasource name. URIs usually embed credentials."),
+		"external_database": optionalString("The database in the external DBMS."),
+		"external_table":    optionalString("The table in the external DBMS."),
+	})
+}
+
+func (m *jdbcEngineModel) engineName() string { return "JDBC" }
+
+func (m *jdbcEngineModel) requirements() []capability { return nil }
+
+func (m *jdbcEngineModel) definition(_ context.Context, _ *diag.Diagnostics) (string, engineSettings) {
+	return "JDBC(" + engineArguments(m.DatasourceURI.ValueString(), m.ExternalDatabase.ValueString(), m.ExternalTable.ValueString()) + ")", nil
+}
+
+func (m *jdbcEngineModel) validate(_ context.Context, blockPath path.Path, diags *diag.Diagnostics) {
+	requireEngineAttributes(blockPath, "JDBC", diags, map[string]attr.Value{
+		"datasource_uri": m.DatasourceURI,
+		"external_table": m.ExternalTable,
+	})
+}
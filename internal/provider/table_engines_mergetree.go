@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// mergeTreeEngineModel maps the mergetree block.
+type mergeTreeEngineModel struct {
+	OrderBy     types.String `tfsdk:"order_by"`
+	PartitionBy types.String `tfsdk:"partition_by"`
+	PrimaryKey  types.String `tfsdk:"primary_key"`
+	TTL         types.String `tfsdk:"ttl"`
+}
+
+func mergeTreeEngineBlock() schema.SingleNestedBlock {
+	return engineBlock("MergeTree engine storing the rows, such as the target of a materialized view reading a queue table.", map[string]schema.Attribute{
+		"order_by":     optionalString("The sorting key expression, e.g. (event_date, event_id). Use tuple() for no sorting. Required."),
+		"partition_by": optionalString("The partition key expression, e.g. toYYYYMM(event_date)."),
+		"primary_key":  optionalString("The primary key expression when it differs from the sorting key."),
+		"ttl":          optionalString("The TTL expression of the rows, e.g. event_date + INTERVAL 30 DAY."),
+	})
+}
+
+func (m *mergeTreeEngineModel) engineName() string { return "MergeTree" }
+
+func (m *mergeTreeEngineModel) requirements() []capability { return nil }
+
+// definition renders the engine followed by its key clauses, which precede
+// the SETTINGS clause of the statement.
+func (m *mergeTreeEngineModel) definition(_ context.Context, _ *diag.Diagnostics) (string, engineSettings) {
+	engine := "MergeTree ORDER BY " + m.OrderBy.ValueString()
+	if !m.PartitionBy.IsNull() {
+		engine += " PARTITION BY " + m.PartitionBy.ValueString()
+	}
+	if !m.PrimaryKey.IsNull() {
+		engine += " PRIMARY KEY " + m.PrimaryKey.ValueString()
+	}
+	if !m.TTL.IsNull() {
+		engine += " TTL " + m.TTL.ValueString()
+	}
+	return engine, engineSettings{}
+}
+
+func (m *mergeTreeEngineModel) validate(_ context.Context, blockPath path.Path, diags *diag.Diagnostics) {
+	if m.OrderBy.IsNull() {
+		diags.AddAttributeError(
+			blockPath.AtName("order_by"),
+			"Missing MergeTree Engine Argument",
+			"The order_by attribute is required. Use tuple() for a table without a sorting key.",
+		)
+	}
+}
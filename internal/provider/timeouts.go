@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	defaultCreateTimeout = 20 * time.Minute
+	defaultReadTimeout   = 5 * time.Minute
+	defaultUpdateTimeout = 20 * time.Minute
+	defaultDeleteTimeout = 20 * time.Minute
+)
+
+// timeoutExceptionCode is the server error code of TIMEOUT_EXCEEDED, which is
+// what max_execution_time and distributed_ddl_task_timeout fail with.
+const timeoutExceptionCode = 159
+
+// operationTimeoutKey is the context key holding the timeout of the current
+// operation.
+type operationTimeoutKey struct{}
+
+// timeoutsBlock returns the timeouts block shared by all resources.
+func timeoutsBlock(ctx context.Context) schema.Block {
+	return timeouts.Block(ctx, timeouts.Opts{
+		Create:            true,
+		Read:              true,
+		Update:            true,
+		Delete:            true,
+		CreateDescription: "How long creating the resource may take, as a duration such as 30s or 1h. Defaults to 20m.",
+		ReadDescription:   "How long reading the resource may take. Defaults to 5m.",
+		UpdateDescription: "How long updating the resource may take. Defaults to 20m.",
+		DeleteDescription: "How long deleting the resource may take. Defaults to 20m.",
+	})
+}
+
+// nullTimeouts returns an unset timeouts block, for state built on import.
+func nullTimeouts() timeouts.Value {
+	return timeouts.Value{
+		Object: types.ObjectNull(map[string]attr.Type{
+			"create": types.StringType,
+			"read":   types.StringType,
+			"update": types.StringType,
+			"delete": types.StringType,
+		}),
+	}
+}
+
+// withOperationTimeout bounds ctx by timeout. The server is given the same
+// limit as max_execution_time and distributed_ddl_task_timeout so that it
+// stops working on statements the provider no longer waits for.
+func withOperationTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	seconds := int(math.Ceil(timeout.Seconds()))
+	ctx = withQuerySettings(ctx, defaultSettings, clickhouse.Settings{
+		"max_execution_time":           seconds,
+		"distributed_ddl_task_timeout": seconds,
+	})
+	ctx = context.WithValue(ctx, operationTimeoutKey{}, timeout)
+	return context.WithTimeout(ctx, timeout)
+}
+
+// timeoutError reports a statement that did not finish within the timeout
+// of the operation running it.
+type timeoutError struct {
+	timeout time.Duration
+	err     error
+}
+
+func (e *timeoutError) Error() string {
+	return fmt.Sprintf("the operation did not finish within its %s timeout, which can be raised in the timeouts block of the resource: %s", e.timeout, e.err)
+}
+
+func (e *timeoutError) Unwrap() error {
+	return e.err
+}
+
+// checkTimeout turns an error caused by the operation timeout into a
+// timeoutError.
+func checkTimeout(ctx context.Context, err error) error {
+	timeout, ok := ctx.Value(operationTimeoutKey{}).(time.Duration)
+	if err == nil || !ok {
+		return err
+	}
+
+	var exception *clickhouse.Exception
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) || errors.Is(err, context.DeadlineExceeded) ||
+		(errors.As(err, &exception) && exception.Code == timeoutExceptionCode) {
+		return &timeoutError{timeout: timeout, err: err}
+	}
+	return err
+}
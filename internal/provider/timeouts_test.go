@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+)
+
+func TestCheckTimeout(t *testing.T) {
+	serverTimeout := &clickhouse.Exception{Code: timeoutExceptionCode, Name: "TIMEOUT_EXCEEDED"}
+	syntaxError := &clickhouse.Exception{Code: 62, Name: "SYNTAX_ERROR"}
+
+	if err := checkTimeout(context.Background(), serverTimeout); err != serverTimeout {
+		t.Errorf("checkTimeout() without an operation timeout = %v, want the error unchanged", err)
+	}
+
+	ctx, cancel := withOperationTimeout(context.Background(), 90*time.Second)
+	defer cancel()
+
+	if err := checkTimeout(ctx, syntaxError); err != syntaxError {
+		t.Errorf("checkTimeout(%v) = %v, want the error unchanged", syntaxError, err)
+	}
+	if err := checkTimeout(ctx, nil); err != nil {
+		t.Errorf("checkTimeout(nil) = %v", err)
+	}
+
+	err := checkTimeout(ctx, serverTimeout)
+	var timeoutErr *timeoutError
+	if !errors.As(err, &timeoutErr) || !errors.Is(err, serverTimeout) {
+		t.Fatalf("checkTimeout(%v) = %v, want a timeoutError wrapping it", serverTimeout, err)
+	}
+	if !strings.Contains(err.Error(), "1m30s timeout") {
+		t.Errorf("timeout error %q does not name the timeout", err)
+	}
+
+	expired, cancelExpired := withOperationTimeout(context.Background(), time.Nanosecond)
+	defer cancelExpired()
+	<-expired.Done()
+	if err := checkTimeout(expired, errors.New("read: i/o timeout")); !errors.As(err, &timeoutErr) {
+		t.Errorf("checkTimeout() after the deadline = %v, want a timeoutError", err)
+	}
+}